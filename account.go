@@ -0,0 +1,103 @@
+package mailerlite
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	accountEndpoint = "/account"
+	statsEndpoint   = "/stats"
+)
+
+type AccountService service
+
+type rootAccount struct {
+	Data Account `json:"data"`
+}
+
+type Account struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	Email         string               `json:"email"`
+	Timezone      Timezone             `json:"timezone"`
+	Subscriptions AccountSubscriptions `json:"subscriptions"`
+	Plan          AccountPlan          `json:"plan"`
+	Settings      AccountSettings      `json:"settings"`
+	CreatedAt     string               `json:"created_at"`
+}
+
+type AccountSettings struct {
+	DoubleOptin bool   `json:"double_optin"`
+	Timezone    string `json:"timezone"`
+}
+
+// DefaultTimezone returns the timezone name campaigns are scheduled in, e.g. Europe/Vilnius
+func (a *Account) DefaultTimezone() string {
+	if a.Timezone.Name != "" {
+		return a.Timezone.Name
+	}
+	return a.Settings.Timezone
+}
+
+// DoubleOptinEnabled reports whether new subscribers have to confirm their subscription
+func (a *Account) DoubleOptinEnabled() bool {
+	return a.Settings.DoubleOptin
+}
+
+type AccountSubscriptions struct {
+	Used  int `json:"used"`
+	Limit int `json:"limit"`
+}
+
+type AccountPlan struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type rootAccountStats struct {
+	Data AccountStats `json:"data"`
+}
+
+type AccountStats struct {
+	Subscribed    int `json:"subscribed"`
+	Unsubscribed  int `json:"unsubscribed"`
+	Unconfirmed   int `json:"unconfirmed"`
+	Bounced       int `json:"bounced"`
+	JunkTotal     int `json:"junk_total"`
+	SentCampaigns int `json:"sent_campaigns"`
+	SentEmails    int `json:"sent_emails"`
+}
+
+// Get - get the account the api key belongs to
+func (s *AccountService) Get(ctx context.Context) (*rootAccount, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, accountEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootAccount)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+// Stats - get the subscriber and sending stats of the account
+func (s *AccountService) Stats(ctx context.Context) (*rootAccountStats, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, statsEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootAccountStats)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
@@ -0,0 +1,140 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanGetAccountStats(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/stats", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"subscribed": 1200,
+					"unsubscribed": 35,
+					"unconfirmed": 4,
+					"bounced": 12,
+					"junk_total": 3,
+					"sent_campaigns": 48,
+					"sent_emails": 51230
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	stats, _, err := client.Account.Stats(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1200, stats.Data.Subscribed)
+	assert.Equal(t, 35, stats.Data.Unsubscribed)
+	assert.Equal(t, 12, stats.Data.Bounced)
+	assert.Equal(t, 3, stats.Data.JunkTotal)
+	assert.Equal(t, 48, stats.Data.SentCampaigns)
+}
+
+func TestCanGetAccount(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/account", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "42",
+					"name": "Acme",
+					"email": "owner@example.com",
+					"timezone": {"id": "1", "name": "Europe/Vilnius", "offset": 10800},
+					"subscriptions": {"used": 1200, "limit": 5000},
+					"plan": {"name": "Growing Business", "type": "monthly"}
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	account, _, err := client.Account.Get(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme", account.Data.Name)
+	assert.Equal(t, "Europe/Vilnius", account.Data.Timezone.Name)
+	assert.Equal(t, 5000, account.Data.Subscriptions.Limit)
+	assert.Equal(t, "Growing Business", account.Data.Plan.Name)
+}
+
+func TestCanReadAccountSettings(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "42",
+					"timezone": {"id": "1", "name": "Europe/Vilnius", "offset": 10800},
+					"settings": {"double_optin": true, "timezone": "Europe/Vilnius"}
+				}
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	account, _, err := client.Account.Get(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Vilnius", account.Data.DefaultTimezone())
+	assert.True(t, account.Data.DoubleOptinEnabled())
+
+	withoutTimezone := mailerlite.Account{Settings: mailerlite.AccountSettings{Timezone: "UTC"}}
+	assert.Equal(t, "UTC", withoutTimezone.DefaultTimezone())
+	assert.False(t, withoutTimezone.DoubleOptinEnabled())
+}
+
+func TestWillReturnNotModifiedForUnchangedStats(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	since := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "Mon, 01 May 2023 10:00:00 GMT", req.Header.Get("If-Modified-Since"))
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`not json`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	ctx := mailerlite.WithIfModifiedSince(context.TODO(), since)
+
+	stats, res, err := client.Account.Stats(ctx)
+
+	assert.ErrorIs(t, err, mailerlite.ErrNotModified)
+	assert.Nil(t, stats)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+}
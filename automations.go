@@ -2,7 +2,6 @@ package mailerlite
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -156,18 +155,17 @@ type StepRun struct {
 // ListAutomationOptions - modifies the behavior of AutomationService.List method
 type ListAutomationOptions struct {
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
+	ListOptions
 }
 
 // ListAutomationSubscriberOptions - modifies the behavior of AutomationService.Subscribers method
 type ListAutomationSubscriberOptions struct {
 	AutomationID string    `url:"-"`
 	Filters      *[]Filter `json:"filters,omitempty"`
-	Page         int       `url:"page,omitempty"`
-	Limit        int       `url:"limit,omitempty"`
+	ListOptions
 }
 
+// List - list of automations
 func (s *AutomationService) List(ctx context.Context, options *ListAutomationOptions) (*rootAutomations, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, automationEndpoint, options)
 	if err != nil {
@@ -183,8 +181,9 @@ func (s *AutomationService) List(ctx context.Context, options *ListAutomationOpt
 	return root, res, nil
 }
 
+// Get - get a single automation
 func (s *AutomationService) Get(ctx context.Context, automationID string) (*rootAutomation, *Response, error) {
-	path := fmt.Sprintf("%s/%s", automationEndpoint, automationID)
+	path := buildPath(automationEndpoint, automationID)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -199,8 +198,9 @@ func (s *AutomationService) Get(ctx context.Context, automationID string) (*root
 	return root, res, nil
 }
 
+// Subscribers - get subscribers activity of an automation
 func (s *AutomationService) Subscribers(ctx context.Context, options *ListAutomationSubscriberOptions) (*rootAutomationsSubscriber, *Response, error) {
-	path := fmt.Sprintf("%s/%s/activity", automationEndpoint, options.AutomationID)
+	path := buildPath(automationEndpoint, options.AutomationID, "activity")
 
 	req, err := s.client.newRequest(http.MethodGet, path, options)
 	if err != nil {
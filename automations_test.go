@@ -0,0 +1,89 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListAutomations(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/automations?filter%5Benabled%5D=true", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{
+						"id": "1",
+						"name": "Welcome",
+						"enabled": true,
+						"stats": {"completed_subscribers_count": 5, "sent": 10}
+					}
+				]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListAutomationOptions{
+		Filters: &[]mailerlite.Filter{{Name: "enabled", Value: true}},
+	}
+
+	automations, _, err := client.Automation.List(ctx, options)
+
+	assert.NoError(t, err)
+	assert.True(t, automations.Data[0].Enabled)
+	assert.Equal(t, 5, automations.Data[0].Stats.CompletedSubscribersCount)
+}
+
+func TestCanListAutomationActivity(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/automations/1/activity?filter%5Bstatus%5D=completed&limit=10&page=2", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{
+						"id": "11",
+						"status": "completed",
+						"subscriber": {"id": "123456789", "email": "client@example.com"}
+					}
+				],
+				"links": {"prev": "https://connect.mailerlite.com/api/automations/1/activity?page=1"},
+				"meta": {"current_page": 2, "last_page": 2}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListAutomationSubscriberOptions{
+		AutomationID: "1",
+		ListOptions:  mailerlite.ListOptions{Page: 2, Limit: 10},
+		Filters:      &[]mailerlite.Filter{{Name: "status", Value: "completed"}},
+	}
+
+	activity, _, err := client.Automation.Subscribers(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "client@example.com", activity.Data[0].Subscriber.Email)
+	assert.True(t, activity.Links.IsLastPage())
+}
@@ -0,0 +1,56 @@
+package mailerlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const batchEndpoint = "/batch"
+
+// MaxBatchRequests is the number of requests the API accepts in a single batch
+const MaxBatchRequests = 50
+
+type BatchService service
+
+type rootBatch struct {
+	Total      int             `json:"total"`
+	Successful int             `json:"successful"`
+	Failed     int             `json:"failed"`
+	Responses  []BatchResponse `json:"responses"`
+}
+
+// BatchRequest - a single request of a batch, the path is relative to the api e.g. api/subscribers
+type BatchRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// BatchResponse - the response to a single request of a batch
+type BatchResponse struct {
+	Code int             `json:"code"`
+	Body json.RawMessage `json:"body"`
+}
+
+// Send - run up to 50 requests in a single call
+func (s *BatchService) Send(ctx context.Context, requests []BatchRequest) (*rootBatch, *Response, error) {
+	if len(requests) > MaxBatchRequests {
+		return nil, nil, fmt.Errorf("a batch can contain at most %d requests, got %d", MaxBatchRequests, len(requests))
+	}
+
+	body := map[string]interface{}{"requests": requests}
+	req, err := s.client.newRequest(http.MethodPost, batchEndpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootBatch)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
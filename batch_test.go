@@ -0,0 +1,65 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSendBatch(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/batch", req.URL.String())
+		assert.JSONEq(t, `{"requests": [
+			{"method": "POST", "path": "api/subscribers", "body": {"email": "client@example.com"}},
+			{"method": "DELETE", "path": "api/groups/1"}
+		]}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"total": 2,
+				"successful": 1,
+				"failed": 1,
+				"responses": [
+					{"code": 201, "body": {"data": {"id": "123456789"}}},
+					{"code": 404, "body": {"message": "Resource not found."}}
+				]
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	requests := []mailerlite.BatchRequest{
+		{Method: http.MethodPost, Path: "api/subscribers", Body: map[string]interface{}{"email": "client@example.com"}},
+		{Method: http.MethodDelete, Path: "api/groups/1"},
+	}
+
+	batch, _, err := client.Batch.Send(context.TODO(), requests)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, batch.Failed)
+	assert.Equal(t, http.StatusCreated, batch.Responses[0].Code)
+	assert.Equal(t, http.StatusNotFound, batch.Responses[1].Code)
+}
+
+func TestWillRejectOversizedBatch(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	requests := make([]mailerlite.BatchRequest, mailerlite.MaxBatchRequests+1)
+
+	batch, res, err := client.Batch.Send(context.TODO(), requests)
+
+	assert.Nil(t, batch)
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "a batch can contain at most 50 requests, got 51")
+}
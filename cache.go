@@ -0,0 +1,44 @@
+package mailerlite
+
+import "sync"
+
+// ResponseCache - stores GET response bodies with their ETag, the client sends the ETag as If-None-Match
+// and serves the stored body when the API answers 304 Not Modified. Keys combine the request URL with
+// a hash of the API key, so responses of different accounts are kept apart.
+type ResponseCache interface {
+	Get(key string) (etag string, body []byte, ok bool)
+	Set(key, etag string, body []byte)
+}
+
+// MemoryResponseCache is a ResponseCache that keeps responses in memory, it is safe for concurrent use
+type MemoryResponseCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	etag string
+	body []byte
+}
+
+// NewMemoryResponseCache - creates an empty in memory response cache
+func NewMemoryResponseCache() *MemoryResponseCache {
+	return &MemoryResponseCache{entries: make(map[string]cacheEntry)}
+}
+
+// Get returns the stored ETag and body for the key
+func (c *MemoryResponseCache) Get(key string) (string, []byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	return entry.etag, entry.body, ok
+}
+
+// Set stores the ETag and body for the key
+func (c *MemoryResponseCache) Set(key, etag string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{etag: etag, body: body}
+}
@@ -2,8 +2,12 @@ package mailerlite
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 const campaignEndpoint = "/campaigns"
@@ -29,7 +33,7 @@ type rootCampaignSubscribers struct {
 }
 
 type rootCampaignLanguages struct {
-	Data []CampaignLanguage
+	Data []CampaignLanguage `json:"data"`
 }
 
 type Campaign struct {
@@ -55,7 +59,7 @@ type Campaign struct {
 	Emails                     []Email            `json:"emails"`
 	UsedInAutomations          bool               `json:"used_in_automations"`
 	TypeForHumans              string             `json:"type_for_humans"`
-	Stats                      Stats              `json:"stats"`
+	Stats                      ResourceStats      `json:"stats"`
 	IsStopped                  bool               `json:"is_stopped"`
 	HasWinner                  interface{}        `json:"has_winner"`
 	WinnerVersionForHuman      interface{}        `json:"winner_version_for_human"`
@@ -81,29 +85,33 @@ type CampaignFilter struct {
 }
 
 type Email struct {
-	ID            string      `json:"id"`
-	AccountID     string      `json:"account_id"`
-	EmailableID   string      `json:"emailable_id"`
-	EmailableType string      `json:"emailable_type"`
-	Type          string      `json:"type"`
-	From          string      `json:"from"`
-	FromName      string      `json:"from_name"`
-	Name          string      `json:"name"`
-	Subject       string      `json:"subject"`
-	PlainText     string      `json:"plain_text"`
-	ScreenshotURL string      `json:"screenshot_url"`
-	PreviewURL    string      `json:"preview_url"`
-	CreatedAt     string      `json:"created_at"`
-	UpdatedAt     string      `json:"updated_at"`
-	IsDesigned    bool        `json:"is_designed"`
-	LanguageID    float64     `json:"language_id"`
-	IsWinner      bool        `json:"is_winner"`
-	Stats         Stats       `json:"stats"`
-	SendAfter     interface{} `json:"send_after"`
-	TrackOpens    bool        `json:"track_opens"`
-}
-
-type Stats struct {
+	ID            string        `json:"id"`
+	AccountID     string        `json:"account_id"`
+	EmailableID   string        `json:"emailable_id"`
+	EmailableType string        `json:"emailable_type"`
+	Type          string        `json:"type"`
+	From          string        `json:"from"`
+	FromName      string        `json:"from_name"`
+	Name          string        `json:"name"`
+	Subject       string        `json:"subject"`
+	PlainText     string        `json:"plain_text"`
+	ScreenshotURL string        `json:"screenshot_url"`
+	PreviewURL    string        `json:"preview_url"`
+	CreatedAt     string        `json:"created_at"`
+	UpdatedAt     string        `json:"updated_at"`
+	IsDesigned    bool          `json:"is_designed"`
+	LanguageID    float64       `json:"language_id"`
+	IsWinner      bool          `json:"is_winner"`
+	Stats         ResourceStats `json:"stats"`
+	SendAfter     interface{}   `json:"send_after"`
+	TrackOpens    bool          `json:"track_opens"`
+}
+
+// Stats is the former name of ResourceStats
+type Stats = ResourceStats
+
+// ResourceStats - engagement stats of a campaign or an email, Group.Stats fills a subset, rates hold both a float and a formatted string
+type ResourceStats struct {
 	Sent              int             `json:"sent"`
 	OpensCount        int             `json:"opens_count"`
 	UniqueOpensCount  int             `json:"unique_opens_count"`
@@ -126,8 +134,7 @@ type Stats struct {
 // ListCampaignOptions - modifies the behavior of CampaignService.List method
 type ListCampaignOptions struct {
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
+	ListOptions
 }
 
 // GetCampaignOptions - modifies the behavior of CampaignService.Get method
@@ -191,6 +198,34 @@ type Schedule struct {
 	TimezoneID int    `json:"timezone_id,omitempty"`
 }
 
+// NewInstantSchedule - returns a schedule that sends the campaign right away
+func NewInstantSchedule() *ScheduleCampaign {
+	return &ScheduleCampaign{Delivery: CampaignScheduleTypeInstant}
+}
+
+// NewTimezoneSchedule - returns a schedule that sends the campaign at the date and time in each subscriber's
+// timezone, the timezone ID is used for subscribers without one
+func NewTimezoneSchedule(date time.Time, timezoneID string) (*ScheduleCampaign, error) {
+	if timezoneID == "" {
+		return nil, errors.New("a timezone based schedule requires a timezone id")
+	}
+
+	id, err := strconv.Atoi(timezoneID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone id %q", timezoneID)
+	}
+
+	return &ScheduleCampaign{
+		Delivery: CampaignScheduleTypeTimezone,
+		Schedule: &Schedule{
+			Date:       date.Format("2006-01-02"),
+			Hours:      date.Format("15"),
+			Minutes:    date.Format("04"),
+			TimezoneID: id,
+		},
+	}, nil
+}
+
 type Resend struct {
 	Delivery   string `json:"delivery"`
 	Date       string `json:"date"`
@@ -199,11 +234,13 @@ type Resend struct {
 	TimezoneID int    `json:"timezone_id,omitempty"`
 }
 
+// CampaignSubscriber - the activity of a single subscriber in a campaign
 type CampaignSubscriber struct {
 	ID          string     `json:"id"`
 	OpensCount  int        `json:"opens_count"`
 	ClicksCount int        `json:"clicks_count"`
 	Subscriber  Subscriber `json:"subscriber"`
+	CreatedAt   Timestamp  `json:"created_at"`
 }
 
 type CampaignLanguage struct {
@@ -214,12 +251,50 @@ type CampaignLanguage struct {
 	Direction string `json:"direction"`
 }
 
+// ListCampaignSubscriberOptions - modifies the behavior of CampaignService.Subscribers method,
+// filter by activity with a "type" filter e.g. NewFilter("type", CampaignActivityOpened)
 type ListCampaignSubscriberOptions struct {
 	CampaignID string    `url:"-"`
 	Filters    *[]Filter `json:"filters,omitempty"`
-	Page       int       `url:"page,omitempty"`
-	Sort       string    `url:"sort,omitempty"`
-	Limit      int       `url:"limit,omitempty"`
+	ListOptions
+	Sort string `url:"sort,omitempty"`
+}
+
+// MarshalJSON encodes the options as the report body, filters are sent as a filter object e.g. {"filter": {"type": "opened"}},
+// a filter with an operator is nested under it like its query form filter[name][operator], e.g. {"filter": {"opens_count": {"gte": 2}}}
+func (o ListCampaignSubscriberOptions) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Filter map[string]interface{} `json:"filter,omitempty"`
+		Page   int                    `json:"page,omitempty"`
+		Sort   string                 `json:"sort,omitempty"`
+		Limit  int                    `json:"limit,omitempty"`
+		Cursor string                 `json:"cursor,omitempty"`
+	}{
+		Page:   o.Page,
+		Sort:   o.Sort,
+		Limit:  o.Limit,
+		Cursor: o.Cursor,
+	}
+
+	if o.Filters != nil && len(*o.Filters) > 0 {
+		body.Filter = make(map[string]interface{}, len(*o.Filters))
+		for _, filter := range *o.Filters {
+			existing, exists := body.Filter[filter.Name]
+			operators, hasOperators := existing.(map[string]interface{})
+			switch {
+			case exists && (filter.Operator == "") == hasOperators:
+				return nil, fmt.Errorf("filter %q cannot have both a value and operators", filter.Name)
+			case filter.Operator == "":
+				body.Filter[filter.Name] = filter.Value
+			case !exists:
+				body.Filter[filter.Name] = map[string]interface{}{filter.Operator: filter.Value}
+			default:
+				operators[filter.Operator] = filter.Value
+			}
+		}
+	}
+
+	return json.Marshal(body)
 }
 
 // List - list of campaigns
@@ -240,7 +315,7 @@ func (s *CampaignService) List(ctx context.Context, options *ListCampaignOptions
 
 // Get - get a single campaign ID
 func (s *CampaignService) Get(ctx context.Context, campaignID string) (*rootCampaign, *Response, error) {
-	path := fmt.Sprintf("%s/%s", campaignEndpoint, campaignID)
+	path := buildPath(campaignEndpoint, campaignID)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -255,6 +330,7 @@ func (s *CampaignService) Get(ctx context.Context, campaignID string) (*rootCamp
 	return root, res, nil
 }
 
+// Create - create a new campaign
 func (s *CampaignService) Create(ctx context.Context, campaign *CreateCampaign) (*rootCampaign, *Response, error) {
 	req, err := s.client.newRequest(http.MethodPost, campaignEndpoint, campaign)
 	if err != nil {
@@ -270,8 +346,9 @@ func (s *CampaignService) Create(ctx context.Context, campaign *CreateCampaign)
 	return root, res, nil
 }
 
+// Update - update a draft campaign
 func (s *CampaignService) Update(ctx context.Context, campaignID string, campaign *UpdateCampaign) (*rootCampaign, *Response, error) {
-	path := fmt.Sprintf("%s/%s", campaignEndpoint, campaignID)
+	path := buildPath(campaignEndpoint, campaignID)
 	req, err := s.client.newRequest(http.MethodPut, path, campaign)
 	if err != nil {
 		return nil, nil, err
@@ -286,8 +363,9 @@ func (s *CampaignService) Update(ctx context.Context, campaignID string, campaig
 	return root, res, nil
 }
 
+// Schedule - schedule a campaign, the schedule is only required for scheduled and timezone based delivery
 func (s *CampaignService) Schedule(ctx context.Context, campaignID string, campaign *ScheduleCampaign) (*rootCampaign, *Response, error) {
-	path := fmt.Sprintf("%s/%s/schedule", campaignEndpoint, campaignID)
+	path := buildPath(campaignEndpoint, campaignID, "schedule")
 	req, err := s.client.newRequest(http.MethodPost, path, campaign)
 	if err != nil {
 		return nil, nil, err
@@ -304,7 +382,7 @@ func (s *CampaignService) Schedule(ctx context.Context, campaignID string, campa
 
 // Cancel - cancel a single campaign
 func (s *CampaignService) Cancel(ctx context.Context, campaignID string) (*rootCampaign, *Response, error) {
-	path := fmt.Sprintf("%s/%s/cancel", campaignEndpoint, campaignID)
+	path := buildPath(campaignEndpoint, campaignID, "cancel")
 	req, err := s.client.newRequest(http.MethodPost, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -321,7 +399,7 @@ func (s *CampaignService) Cancel(ctx context.Context, campaignID string) (*rootC
 
 // Subscribers - get subscribers activity of a campaign
 func (s *CampaignService) Subscribers(ctx context.Context, options *ListCampaignSubscriberOptions) (*rootCampaignSubscribers, *Response, error) {
-	path := fmt.Sprintf("%s/%s/reports/subscriber-activity", campaignEndpoint, options.CampaignID)
+	path := buildPath(campaignEndpoint, options.CampaignID, "reports", "subscriber-activity")
 
 	req, err := s.client.newRequest(http.MethodPost, path, options)
 	if err != nil {
@@ -337,8 +415,50 @@ func (s *CampaignService) Subscribers(ctx context.Context, options *ListCampaign
 	return root, res, nil
 }
 
+// AllSubscribers - iterate over the subscriber activity of a campaign, pages are fetched lazily while iterating
+func (s *CampaignService) AllSubscribers(ctx context.Context, options *ListCampaignSubscriberOptions) *CampaignSubscriberIterator {
+	listOptions := ListCampaignSubscriberOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+
+	return &CampaignSubscriberIterator{pages: pageIterator[CampaignSubscriber]{
+		ctx:  ctx,
+		next: PageRequest{Page: listOptions.Page, Cursor: listOptions.Cursor},
+		fetch: func(ctx context.Context, page PageRequest) ([]CampaignSubscriber, Paginated, error) {
+			listOptions.Page, listOptions.Cursor = page.Page, page.Cursor
+			root, _, err := s.Subscribers(ctx, &listOptions)
+			if err != nil {
+				return nil, nil, err
+			}
+			return root.Data, root, nil
+		},
+	}}
+}
+
+// CampaignSubscriberIterator iterates over the subscriber activity returned by CampaignService.AllSubscribers
+type CampaignSubscriberIterator struct {
+	pages pageIterator[CampaignSubscriber]
+}
+
+// Next advances to the next subscriber activity, it returns false when there is no more activity or an error occurred
+func (it *CampaignSubscriberIterator) Next() bool {
+	return it.pages.advance()
+}
+
+// Subscriber returns the current subscriber activity
+func (it *CampaignSubscriberIterator) Subscriber() *CampaignSubscriber {
+	return it.pages.current
+}
+
+// Err returns the error that stopped the iteration
+func (it *CampaignSubscriberIterator) Err() error {
+	return it.pages.err
+}
+
+// Languages - list of languages available for campaigns
 func (s *CampaignService) Languages(ctx context.Context) (*rootCampaignLanguages, *Response, error) {
-	path := fmt.Sprintf("%s/languages", campaignEndpoint)
+	path := buildPath(campaignEndpoint, "languages")
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -353,8 +473,9 @@ func (s *CampaignService) Languages(ctx context.Context) (*rootCampaignLanguages
 	return root, res, nil
 }
 
+// Delete - delete a campaign
 func (s *CampaignService) Delete(ctx context.Context, campaignID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", campaignEndpoint, campaignID)
+	path := buildPath(campaignEndpoint, campaignID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -0,0 +1,518 @@
+package mailerlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListCampaignsByStatus(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns?filter%5Bstatus%5D=sent&filter%5Btype%5D=regular", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{"id": "1", "name": "Launch", "type": "regular", "status": "sent"}],
+				"meta": {"aggregations": {"total": 3, "draft": 1, "ready": 1, "sent": 1}}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListCampaignOptions{
+		Filters: &[]mailerlite.Filter{
+			{Name: "status", Value: mailerlite.CampaignStatusSent},
+			{Name: "type", Value: mailerlite.CampaignTypeRegular},
+		},
+	}
+
+	campaigns, _, err := client.Campaign.List(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sent", campaigns.Data[0].Status)
+	assert.Equal(t, 1, campaigns.Meta.Aggregations.Sent)
+}
+
+func TestCanGetCampaign(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "1",
+					"name": "Launch",
+					"type": "regular",
+					"status": "ready",
+					"scheduled_for": "2023-05-01 10:00:00",
+					"emails": [{"id": "2", "subject": "We are live"}],
+					"stats": {"sent": 100, "open_rate": {"float": 0.3, "string": "30%"}}
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	campaign, _, err := client.Campaign.Get(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-05-01 10:00:00", campaign.Data.ScheduledFor)
+	assert.Equal(t, "We are live", campaign.Data.Emails[0].Subject)
+	assert.Equal(t, 100, campaign.Data.Stats.Sent)
+	assert.Equal(t, 0.3, campaign.Data.Stats.OpenRate.Float)
+}
+
+func TestCanCreateCampaign(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns", req.URL.String())
+		assert.JSONEq(t, `{
+			"name": "Launch",
+			"type": "regular",
+			"emails": [{"subject": "We are live", "from_name": "MailerLite", "from": "info@example.com", "content": "<p>Hi</p>"}],
+			"groups": ["1"]
+		}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Launch", "status": "draft"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	campaign := &mailerlite.CreateCampaign{
+		Name: "Launch",
+		Type: mailerlite.CampaignTypeRegular,
+		Emails: []mailerlite.Emails{{
+			Subject:  "We are live",
+			FromName: "MailerLite",
+			From:     "info@example.com",
+			Content:  "<p>Hi</p>",
+		}},
+		Groups: []string{"1"},
+	}
+
+	newCampaign, _, err := client.Campaign.Create(ctx, campaign)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "draft", newCampaign.Data.Status)
+}
+
+func TestCanUpdateCampaign(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Relaunch"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	campaign := &mailerlite.UpdateCampaign{
+		Name: "Relaunch",
+		Type: mailerlite.CampaignTypeRegular,
+	}
+
+	updatedCampaign, _, err := client.Campaign.Update(ctx, "1", campaign)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Relaunch", updatedCampaign.Data.Name)
+}
+
+func TestCanDeleteCampaign(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Campaign.Delete(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestCanScheduleCampaign(t *testing.T) {
+	tests := []struct {
+		schedule *mailerlite.ScheduleCampaign
+		body     string
+	}{
+		{
+			schedule: &mailerlite.ScheduleCampaign{Delivery: mailerlite.CampaignScheduleTypeInstant},
+			body:     `{"delivery":"instant"}`,
+		},
+		{
+			schedule: &mailerlite.ScheduleCampaign{
+				Delivery: mailerlite.CampaignScheduleTypeScheduled,
+				Schedule: &mailerlite.Schedule{Date: "2023-05-01", Hours: "10", Minutes: "30"},
+			},
+			body: `{"delivery":"scheduled","schedule":{"date":"2023-05-01","hours":"10","minutes":"30"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		client := mailerlite.NewClient(testKey)
+
+		testClient := NewTestClient(func(req *http.Request) *http.Response {
+			body, _ := io.ReadAll(req.Body)
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/1/schedule", req.URL.String())
+			assert.JSONEq(t, tt.body, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "status": "ready"}}`)),
+			}
+		})
+
+		client.SetHttpClient(testClient)
+
+		campaign, _, err := client.Campaign.Schedule(context.TODO(), "1", tt.schedule)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ready", campaign.Data.Status)
+	}
+}
+
+func TestCanCancelCampaign(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/1/cancel", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "status": "draft"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	campaign, _, err := client.Campaign.Cancel(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "draft", campaign.Data.Status)
+}
+
+func TestCanListCampaignLanguages(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/languages", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{"id": "4", "shortcode": "en", "iso639": "en-US", "name": "English", "direction": "ltr"},
+					{"id": "23", "shortcode": "he", "iso639": "he-IL", "name": "Hebrew", "direction": "rtl"}
+				]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	languages, _, err := client.Campaign.Languages(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(languages.Data))
+	assert.Equal(t, "en", languages.Data[0].Shortcode)
+	assert.Equal(t, "rtl", languages.Data[1].Direction)
+}
+
+func TestWillSendEmptyBodyWithoutPayload(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Nil(t, req.Body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "status": "draft"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Campaign.Cancel(context.TODO(), "1")
+
+	assert.NoError(t, err)
+}
+
+func TestCanDecodeCampaignStats(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{
+					"id": "1",
+					"stats": {
+						"sent": 200,
+						"opens_count": 80,
+						"open_rate": {"float": 0.4, "string": "40%"},
+						"clicks_count": 20,
+						"click_rate": {"float": 0.1, "string": "10%"},
+						"unsubscribes_count": 2,
+						"unsubscribe_rate": {"float": 0.01, "string": "1%"}
+					}
+				}]
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	campaigns, _, err := client.Campaign.List(context.TODO(), nil)
+
+	assert.NoError(t, err)
+
+	stats := campaigns.Data[0].Stats
+	assert.Equal(t, 200, stats.Sent)
+	assert.Equal(t, 80, stats.OpensCount)
+	assert.Equal(t, "40%", stats.OpenRate.String)
+	assert.Equal(t, 0.1, stats.ClickRate.Float)
+	assert.Equal(t, 2, stats.UnsubscribesCount)
+	assert.Equal(t, "1%", stats.UnsubscribeRate.String)
+}
+
+func TestWillOmitUnsetCampaignPointerOptions(t *testing.T) {
+	create, err := json.Marshal(&mailerlite.CreateCampaign{Name: "Launch", Type: mailerlite.CampaignTypeRegular})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(create), "ab_settings")
+	assert.NotContains(t, string(create), "resend_settings")
+
+	schedule, err := json.Marshal(&mailerlite.ScheduleCampaign{Delivery: mailerlite.CampaignScheduleTypeInstant})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"delivery": "instant"}`, string(schedule))
+}
+
+func TestCanListOpenedCampaignSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/campaigns/1234/reports/subscriber-activity", req.URL.String())
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"filter": {"type": "opened"}, "limit": 10}`, string(body))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{
+					"id": "5",
+					"opens_count": 2,
+					"clicks_count": 1,
+					"subscriber": {"id": "77", "email": "reader@example.com"},
+					"created_at": "2023-05-01 10:15:00"
+				}]
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListCampaignSubscriberOptions{
+		CampaignID:  "1234",
+		Filters:     &[]mailerlite.Filter{*mailerlite.NewFilter("type", mailerlite.CampaignActivityOpened)},
+		ListOptions: mailerlite.ListOptions{Limit: 10},
+	}
+
+	activity, _, err := client.Campaign.Subscribers(context.TODO(), options)
+
+	assert.NoError(t, err)
+	assert.Len(t, activity.Data, 1)
+	assert.Equal(t, 2, activity.Data[0].OpensCount)
+	assert.Equal(t, "reader@example.com", activity.Data[0].Subscriber.Email)
+	assert.Equal(t, time.Date(2023, 5, 1, 10, 15, 0, 0, time.UTC), activity.Data[0].CreatedAt.Time)
+}
+
+func TestCanIterateCampaignSubscriberActivity(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var cursors []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		var body struct {
+			Cursor string `json:"cursor"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		cursors = append(cursors, body.Cursor)
+
+		response := `{
+			"data": [{"id": "1", "opens_count": 1}, {"id": "2", "opens_count": 3}],
+			"links": {"next": "https://connect.mailerlite.com/api/campaigns/1234/reports/subscriber-activity?cursor=second"}
+		}`
+		switch body.Cursor {
+		case "second":
+			response = `{
+				"data": [{"id": "3", "opens_count": 2}],
+				"links": {"next": "https://connect.mailerlite.com/api/campaigns/1234/reports/subscriber-activity?cursor=third"}
+			}`
+		case "third":
+			response = `{"data": [], "links": {"next": "https://connect.mailerlite.com/api/campaigns/1234/reports/subscriber-activity?cursor=fourth"}}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(response)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListCampaignSubscriberOptions{
+		CampaignID: "1234",
+		Filters:    &[]mailerlite.Filter{*mailerlite.NewFilter("type", mailerlite.CampaignActivityOpened)},
+	}
+
+	var ids []string
+	activity := client.Campaign.AllSubscribers(context.TODO(), options)
+	for activity.Next() {
+		ids = append(ids, activity.Subscriber().ID)
+	}
+
+	assert.NoError(t, activity.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, []string{"", "second", "third"}, cursors)
+}
+
+func TestCanIterateCampaignSubscriberActivityByPageNumber(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var pages []int
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		var body struct {
+			Page int `json:"page"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		pages = append(pages, body.Page)
+
+		response := `{
+			"data": [{"id": "1"}, {"id": "2"}],
+			"links": {"next": "https://connect.mailerlite.com/api/campaigns/1234/reports/subscriber-activity?page=2"}
+		}`
+		if body.Page == 2 {
+			response = `{"data": [{"id": "3"}], "links": {"next": null}}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(response)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	var ids []string
+	activity := client.Campaign.AllSubscribers(context.TODO(), &mailerlite.ListCampaignSubscriberOptions{CampaignID: "1234"})
+	for activity.Next() {
+		ids = append(ids, activity.Subscriber().ID)
+	}
+
+	assert.NoError(t, activity.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, []int{0, 2}, pages)
+}
+
+func TestCanEncodeCampaignSubscriberFilterOperators(t *testing.T) {
+	body, err := json.Marshal(&mailerlite.ListCampaignSubscriberOptions{
+		CampaignID: "1234",
+		Filters: &[]mailerlite.Filter{
+			*mailerlite.NewFilter("type", mailerlite.CampaignActivityOpened),
+			{Name: "opens_count", Operator: "gte", Value: 2},
+			{Name: "opens_count", Operator: "lte", Value: 5},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"filter": {"type": "opened", "opens_count": {"gte": 2, "lte": 5}}}`, string(body))
+
+	_, err = json.Marshal(&mailerlite.ListCampaignSubscriberOptions{
+		Filters: &[]mailerlite.Filter{
+			{Name: "opens_count", Value: 2},
+			{Name: "opens_count", Operator: "gte", Value: 2},
+		},
+	})
+
+	assert.ErrorContains(t, err, `filter "opens_count" cannot have both a value and operators`)
+}
+
+func TestCanBuildSchedulePayloads(t *testing.T) {
+	instant, err := json.Marshal(mailerlite.NewInstantSchedule())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"delivery": "instant"}`, string(instant))
+
+	schedule, err := mailerlite.NewTimezoneSchedule(time.Date(2023, 5, 1, 9, 5, 0, 0, time.UTC), "123")
+	assert.NoError(t, err)
+
+	timezoneBased, err := json.Marshal(schedule)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"delivery": "timezone_based",
+		"schedule": {"date": "2023-05-01", "hours": "09", "minutes": "05", "timezone_id": 123}
+	}`, string(timezoneBased))
+
+	_, err = mailerlite.NewTimezoneSchedule(time.Now(), "")
+	assert.EqualError(t, err, "a timezone based schedule requires a timezone id")
+
+	_, err = mailerlite.NewTimezoneSchedule(time.Now(), "Europe/Vilnius")
+	assert.EqualError(t, err, `invalid timezone id "Europe/Vilnius"`)
+}
@@ -2,8 +2,12 @@ package mailerlite
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +17,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-querystring/query"
 )
@@ -28,22 +33,90 @@ const (
 	HeaderRateLimit      = "X-RateLimit-Limit"
 	HeaderRateRemaining  = "X-RateLimit-Remaining"
 	HeaderRateRetryAfter = "Retry-After"
+	HeaderIdempotencyKey = "Idempotency-Key"
 )
 
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey - returns a context that sends the key as Idempotency-Key header, so a retried
+// create or import is not applied twice
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey - returns a context whose requests are authorized with the key instead of the client key,
+// so a single client can serve many accounts
+func WithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, apiKey)
+}
+
+type ifModifiedSinceContextKey struct{}
+
+// ErrNotModified is returned when the resource did not change since the time passed to WithIfModifiedSince,
+// the response is not decoded so the caller can keep its previous value
+var ErrNotModified = errors.New("mailerlite: not modified")
+
+// WithIfModifiedSince - returns a context that sends If-Modified-Since, e.g. when polling stats
+func WithIfModifiedSince(ctx context.Context, since time.Time) context.Context {
+	return context.WithValue(ctx, ifModifiedSinceContextKey{}, since)
+}
+
+type headersContextKey struct{}
+
+// protectedHeaders can not be overwritten with WithHeader to avoid sending the wrong credentials
+var protectedHeaders = map[string]bool{
+	"Authorization": true,
+	"Content-Type":  true,
+}
+
+// WithHeader - returns a context that adds the header to every request made with it, e.g. a tracing ID.
+// Authorization and Content-Type can not be overwritten, User-Agent and Accept can, e.g. to ask for text/csv.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	headers := http.Header{}
+	if existing, ok := ctx.Value(headersContextKey{}).(http.Header); ok {
+		headers = existing.Clone()
+	}
+	headers.Set(key, value)
+	return context.WithValue(ctx, headersContextKey{}, headers)
+}
+
 // Client - base api client
 type Client struct {
 	clientMu sync.Mutex   // clientMu protects the client during calls that modify the CheckRedirect func.
 	client   *http.Client // HTTP client used to communicate with the API.
 
+	configMu sync.RWMutex // configMu protects the configuration below while requests are in flight.
+
 	apiBase    *url.URL // apiBase the base used when communicating with the API.
 	apiVersion string   // apiVersion the version used when communicating with the API.
 	apiKey     string   // apiKey used when communicating with the API.
+	apiKeys    []string // apiKeys are tried in order when the API rejects the current key.
 
 	userAgent string // userAgent User agent used when communicating with the API.
 
 	rateMu     sync.Mutex // rateMu protects the rate during getting rate limits from client
 	rateLimits Rate       // Rate limits for the client as determined by the most recent API calls.
 
+	retryConfig RetryConfig // retryConfig controls retrying of rate limited and failed requests.
+
+	debugLogger DebugLogger // debugLogger is called with every request and response.
+
+	compression bool // compression asks the API for gzip encoded responses.
+
+	responseCache ResponseCache // responseCache enables conditional GET requests.
+
+	rawBody bool // rawBody keeps the undecoded response body on Response.
+
+	language string // language is sent as Accept-Language to get localized messages.
+
+	timeout time.Duration // timeout is applied to a copy of the http client once all options ran.
+
+	middleware []Middleware // middleware wraps the transport of every request.
+
+	composedClient *http.Client // composedClient is client with the middleware composed around its transport.
+
 	common service // common service
 
 	Subscriber *SubscriberService // Subscriber service
@@ -55,7 +128,10 @@ type Client struct {
 	Campaign   *CampaignService   // Campaign service
 	Automation *AutomationService // Automation service
 	Timezone   *TimezoneService   // Timezone service
+	Account    *AccountService    // Account service
+	Batch      *BatchService      // Batch service
 
+	EmailVerification *EmailVerificationService // Email verification service
 }
 
 type service struct {
@@ -69,15 +145,42 @@ type Response struct {
 	// Explicitly specify the Rate type so Rate's String() receiver doesn't
 	// propagate to Response.
 	Rate Rate
+
+	// RawBody is the undecoded response body, it is only set when the client was created WithRawBody.
+	RawBody []byte
+}
+
+// Created reports whether the request created a resource, e.g. an upsert of a new subscriber
+func (r *Response) Created() bool {
+	return r != nil && r.Response != nil && r.StatusCode == http.StatusCreated
 }
 
 // ErrorResponse is a MailerLite API error response. This wraps the standard http.Response
 type ErrorResponse struct {
-	Response *http.Response      // HTTP response that caused this error
-	Message  string              `json:"message"` // error message
-	Errors   map[string][]string `json:"errors"`
+	Response    *http.Response      // HTTP response that caused this error
+	Message     string              `json:"message"` // error message
+	Errors      map[string][]string `json:"errors"`
+	ContentType string              `json:"-"` // content type of the error body
+}
+
+// RetryConfig controls how requests that were rate limited, failed with a 5xx or got no response are retried.
+// Rate limited requests are always retried, the others only when they are idempotent: GET, PUT and DELETE
+// requests or requests sent with WithIdempotencyKey.
+type RetryConfig struct {
+	// The number of retries after the first attempt, 0 disables retrying.
+	MaxRetries int
+
+	// The wait before the first retry, doubled on every following retry.
+	MinWait time.Duration
+
+	// The upper limit of a single wait, 0 means no limit.
+	MaxWait time.Duration
 }
 
+// DebugLogger is called after every request sent to the API. The Authorization header of the request
+// is redacted and the response body must not be read, resp is nil when err is not.
+type DebugLogger func(req *http.Request, resp *http.Response, err error)
+
 // Rate represents the rate limit for the current client.
 type Rate struct {
 	// The number of requests per minute the client is currently limited to.
@@ -96,13 +199,82 @@ func (r *ErrorResponse) Error() string {
 		r.Response.StatusCode, r.Message, r.Errors)
 }
 
+// FieldErrors returns the validation messages for a single field
+func (r *ErrorResponse) FieldErrors(field string) []string {
+	return r.Errors[field]
+}
+
 // AuthError occurs when using HTTP Authentication fails
 type AuthError ErrorResponse
 
 func (r *AuthError) Error() string { return (*ErrorResponse)(r).Error() }
 
+// NotFoundError occurs when the requested resource does not exist
+type NotFoundError ErrorResponse
+
+func (r *NotFoundError) Error() string { return (*ErrorResponse)(r).Error() }
+
+// Middleware wraps the transport of the client, e.g. to record metrics or tracing spans around every request
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// ClientOption - configures the client created by NewClient
+type ClientOption func(*Client)
+
+// WithHTTPClient - use the given http client to communicate with the API
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = httpClient
+	}
+}
+
+// WithBaseURL - use the given base url to communicate with the API, an invalid url is ignored
+func WithBaseURL(base string) ClientOption {
+	return func(c *Client) {
+		_ = c.SetAPIBase(base)
+	}
+}
+
+// WithUserAgent - send the given user agent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout - limit the time of every request, it applies to a copy of the final http client
+// whatever the order of the options, so a shared client is not modified
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithCompression - ask the API for gzip encoded responses, they are decompressed transparently
+func WithCompression() ClientOption {
+	return func(c *Client) {
+		c.compression = true
+	}
+}
+
+// WithRawBody - keep the raw JSON of every successful response in Response.RawBody
+func WithRawBody() ClientOption {
+	return func(c *Client) {
+		c.rawBody = true
+	}
+}
+
+// DefaultTransport - returns a transport tuned for MailerLite that keeps idle connections around for reuse,
+// use it with WithHTTPClient or SetHttpClient, e.g. client.SetHttpClient(&http.Client{Transport: DefaultTransport()})
+func DefaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 20
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
 // NewClient - creates a new client instance.
-func NewClient(apiKey string) *Client {
+func NewClient(apiKey string, opts ...ClientOption) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
 
 	client := &Client{
@@ -122,46 +294,188 @@ func NewClient(apiKey string) *Client {
 	client.Campaign = (*CampaignService)(&client.common)
 	client.Automation = (*AutomationService)(&client.common)
 	client.Timezone = (*TimezoneService)(&client.common)
+	client.Account = (*AccountService)(&client.common)
+	client.Batch = (*BatchService)(&client.common)
+	client.EmailVerification = (*EmailVerificationService)(&client.common)
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.timeout > 0 {
+		httpClient := *client.client
+		httpClient.Timeout = client.timeout
+		client.client = &httpClient
+	}
+
+	client.composeMiddleware()
 
 	return client
 }
 
 // APIKey - Get api key after it has been created
 func (c *Client) APIKey() string {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
 	return c.apiKey
 }
 
 // Client - Get the current client
 func (c *Client) Client() *http.Client {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
 	return c.client
 }
 
 // SetHttpClient - Set the client if you want more control over the client implementation
 func (c *Client) SetHttpClient(client *http.Client) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
 	c.client = client
+	c.composeMiddleware()
 }
 
 // SetAPIKey - Set the client api key
 func (c *Client) SetAPIKey(apikey string) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
 	c.apiKey = apikey
+	c.apiKeys = nil
+}
+
+// SetAPIKeys - Set several api keys, the first one is used and a request rejected with 401
+// is retried once with the next one, which is kept when accepted. This allows rotating keys without downtime
+func (c *Client) SetAPIKeys(apiKeys []string) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.apiKeys = append([]string(nil), apiKeys...)
+	c.apiKey = ""
+	if len(apiKeys) > 0 {
+		c.apiKey = apiKeys[0]
+	}
+}
+
+// SetUserAgent - Set the user agent sent with every request
+func (c *Client) SetUserAgent(userAgent string) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.userAgent = userAgent
+}
+
+// SetAPIBase - Set the base url of the API, useful for proxies and mock servers
+func (c *Client) SetAPIBase(base string) error {
+	baseURL, err := url.Parse(strings.TrimRight(base, "/"))
+	if err != nil {
+		return err
+	}
+
+	c.configMu.Lock()
+	c.apiBase = baseURL
+	c.configMu.Unlock()
+
+	return nil
+}
+
+// SetLanguage - Set the Accept-Language header so MailerLite returns localized messages where it can
+func (c *Client) SetLanguage(lang string) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.language = lang
+}
+
+// Use - Add middleware around every request, the first middleware added is the outermost
+func (c *Client) Use(middleware ...Middleware) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.middleware = append(c.middleware, middleware...)
+	c.composeMiddleware()
+}
+
+// SetDebugLogger - Set a function that is called with every request and response
+func (c *Client) SetDebugLogger(logger DebugLogger) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.debugLogger = logger
+}
+
+// SetResponseCache - Set a cache for GET responses, unchanged resources are served from it on 304 Not Modified
+func (c *Client) SetResponseCache(cache ResponseCache) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.responseCache = cache
+}
+
+// SetRetryConfig - Set how rate limited (429), failed (5xx) and unanswered requests are retried
+func (c *Client) SetRetryConfig(config RetryConfig) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.retryConfig = config
+}
+
+// NewRequest - create an API request with the client headers, the path is relative to the API base and starts with / e.g. /subscribers.
+// The body is sent as JSON for POST, PUT, PATCH and DELETE requests and encoded as query params for GET requests.
+func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	return c.newRequest(method, path, body)
+}
+
+// streamResponse tells do to leave the response body open and undecoded
+type streamResponse struct{}
+
+// DoStream - send a request without decoding the response, e.g. to read a large export incrementally.
+// On success the caller owns the open Response.Body and must close it, on error the body is already closed.
+func (c *Client) DoStream(ctx context.Context, req *http.Request) (*Response, error) {
+	return c.do(ctx, req, streamResponse{})
+}
+
+// buildPath joins the endpoint and the escaped path segments, e.g. an email used as subscriber id
+func buildPath(endpoint string, segments ...string) string {
+	var path strings.Builder
+	path.WriteString("/" + strings.Trim(endpoint, "/"))
+	for _, segment := range segments {
+		path.WriteString("/" + url.PathEscape(segment))
+	}
+	return path.String()
 }
 
 func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("request path %q must start with /", path)
+	}
+
 	reqURL := fmt.Sprintf("%s%s", c.apiBase, path)
-	reqBodyBytes := new(bytes.Buffer)
+	// the body reader is only attached when there is content to send
+	var reqBody io.Reader
 
-	if method == http.MethodPost ||
-		method == http.MethodPut ||
-		method == http.MethodDelete {
-		err := json.NewEncoder(reqBodyBytes).Encode(body)
-		if err != nil {
+	// list options are validated whether they are sent as query params or in the body
+	if options, ok := body.(interface{ validateListOptions() error }); ok && !isNilPointer(body) {
+		if err := options.validateListOptions(); err != nil {
 			return nil, err
 		}
-	} else if method == http.MethodGet {
-		reqURL, _ = addOptions(reqURL, body)
 	}
 
-	req, err := http.NewRequest(method, reqURL, reqBodyBytes)
+	// a nil body, or a nil options pointer, is not encoded, json would send a literal null
+	if body != nil && !isNilPointer(body) {
+		switch method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			reqBodyBytes := new(bytes.Buffer)
+			err := json.NewEncoder(reqBodyBytes).Encode(body)
+			if err != nil {
+				return nil, err
+			}
+			reqBody = reqBodyBytes
+		case http.MethodGet:
+			var err error
+			reqURL, err = addOptions(reqURL, body)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	req, err := http.NewRequest(method, reqURL, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -176,37 +490,333 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Accept", "application/json")
 
+	if c.compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+
 	return req, nil
 }
 
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
 	req = req.WithContext(ctx)
-	resp, err := c.client.Do(req)
-	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+
+	// the configuration is read once so it can change while the request is in flight
+	c.configMu.RLock()
+	httpClient := c.httpClient()
+	retryConfig := c.retryConfig
+	debugLogger := c.debugLogger
+	responseCache := c.responseCache
+	rawBody := c.rawBody
+	apiKey := c.apiKey
+	fallbackKey := c.nextAPIKey()
+	c.configMu.RUnlock()
+
+	// a request scoped key is never swapped for one of the client keys
+	if apiKey, ok := ctx.Value(apiKeyContextKey{}).(string); ok && apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		fallbackKey = ""
+	}
+
+	if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+
+	if since, ok := ctx.Value(ifModifiedSinceContextKey{}).(time.Time); ok && !since.IsZero() {
+		req.Header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+	}
+
+	if headers, ok := ctx.Value(headersContextKey{}).(http.Header); ok {
+		for key, values := range headers {
+			if !protectedHeaders[key] {
+				req.Header[key] = values
+			}
 		}
-		return nil, err
 	}
 
-	response := newResponse(resp)
+	// responses are only cached for GET requests and when a cache is set
+	var cacheKey string
+	_, stream := v.(streamResponse)
+	if responseCache != nil && req.Method == http.MethodGet && !stream {
+		cacheKey = responseCacheKey(req)
+		if etag, _, ok := responseCache.Get(cacheKey); ok {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	// swappedKey is the fallback key sent after the current one was rejected
+	var swappedKey string
+	sent := false
+	for attempt := 0; ; attempt++ {
+		if sent && req.GetBody != nil {
+			// newRequest buffers the body, so it can be replayed on every retry
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := httpClient.Do(req)
+		sent = true
+
+		if debugLogger != nil {
+			debugLogger(redactRequest(req), resp, err)
+		}
+
+		if err != nil {
+			// a cancelled request is not a transport failure
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			// the API may have handled the request before the connection failed
+			if attempt < retryConfig.MaxRetries && isIdempotent(req) {
+				if err := sleep(ctx, retryConfig.backoff(attempt, Rate{})); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			return nil, &TransportError{Err: err}
+		}
+
+		if resp.Header.Get("Content-Encoding") == "gzip" {
+			body, err := newGzipBody(resp.Body)
+			if err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			resp.Body = body
+		}
+
+		response := newResponse(resp)
+
+		if swappedKey != "" && resp.StatusCode != http.StatusUnauthorized {
+			c.promoteAPIKey(apiKey, swappedKey)
+			swappedKey = ""
+		}
+
+		if cacheKey != "" && resp.StatusCode == http.StatusNotModified {
+			if _, body, ok := responseCache.Get(cacheKey); ok {
+				drainAndClose(resp.Body)
+				if rawBody {
+					response.RawBody = body
+				}
+				if v != nil {
+					if err := json.Unmarshal(body, v); err != nil {
+						return nil, err
+					}
+				}
+				return response, nil
+			}
+		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			drainAndClose(resp.Body)
+			return response, ErrNotModified
+		}
+
+		err = checkResponse(resp)
+		if err != nil {
+			drainAndClose(resp.Body)
+
+			if _, ok := err.(*AuthError); ok && fallbackKey != "" {
+				req.Header.Set("Authorization", "Bearer "+fallbackKey)
+				swappedKey, fallbackKey = fallbackKey, ""
+				// swapping the key is not a retry of the same request
+				attempt--
+				continue
+			}
+
+			if attempt < retryConfig.MaxRetries && shouldRetry(req, resp) {
+				if err := sleep(ctx, retryConfig.backoff(attempt, response.Rate)); err != nil {
+					return response, err
+				}
+				continue
+			}
+
+			return response, err
+		}
+
+		if stream {
+			// the caller of DoStream owns the body
+			return response, nil
+		}
+
+		defer drainAndClose(resp.Body)
+
+		etag := resp.Header.Get("ETag")
+		if rawBody || (cacheKey != "" && etag != "") {
+			// the body is buffered so it can be kept and still decoded below
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			if rawBody {
+				response.RawBody = body
+			}
+			if cacheKey != "" && etag != "" {
+				responseCache.Set(cacheKey, etag, body)
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		if v != nil {
+			err = json.NewDecoder(resp.Body).Decode(v)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	err = checkResponse(resp)
-	if err != nil {
-		defer resp.Body.Close()
 		return response, err
 	}
+}
 
-	if v != nil {
-		err = json.NewDecoder(resp.Body).Decode(v)
-		if err != nil {
-			return nil, err
+// nextAPIKey returns the key following the current one in apiKeys, empty when there is none.
+// The caller holds configMu.
+func (c *Client) nextAPIKey() string {
+	for i, key := range c.apiKeys {
+		if key == c.apiKey && i+1 < len(c.apiKeys) {
+			return c.apiKeys[i+1]
 		}
 	}
+	return ""
+}
+
+// promoteAPIKey makes the fallback key that was accepted the current one, so later requests do not
+// send the rejected key first. Nothing changes when the key was set again in the meantime.
+func (c *Client) promoteAPIKey(rejected, accepted string) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	if c.apiKey == rejected {
+		c.apiKey = accepted
+	}
+}
+
+// httpClient returns the http client with the middleware composed around its transport.
+// The caller holds configMu.
+func (c *Client) httpClient() *http.Client {
+	if c.composedClient == nil {
+		return c.client
+	}
+	return c.composedClient
+}
+
+// composeMiddleware wraps the transport of the http client in the middleware once, so state a middleware
+// sets up when it is composed is shared by every request. The caller holds configMu.
+func (c *Client) composeMiddleware() {
+	if len(c.middleware) == 0 {
+		c.composedClient = c.client
+		return
+	}
+
+	transport := c.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		transport = c.middleware[i](transport)
+	}
+
+	client := *c.client
+	client.Transport = transport
+	c.composedClient = &client
+}
+
+// gzipBody decompresses a response body and closes both the gzip reader and the body
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func newGzipBody(body io.ReadCloser) (*gzipBody, error) {
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipBody{Reader: reader, body: body}, nil
+}
+
+func (b *gzipBody) Close() error {
+	err := b.Reader.Close()
+	if bodyErr := b.body.Close(); err == nil {
+		err = bodyErr
+	}
+	return err
+}
+
+// maxDrainBytes limits how much of an unread body is discarded, bigger leftovers are cheaper to drop with the connection
+const maxDrainBytes = 64 << 10
+
+// drainAndClose reads what is left of a body and closes it, a fully read body lets the transport reuse the connection
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
+
+// redactRequest returns a copy of the request that is safe to log
+func redactRequest(req *http.Request) *http.Request {
+	redacted := req.Clone(req.Context())
+	if redacted.Header.Get("Authorization") != "" {
+		redacted.Header.Set("Authorization", "Bearer [REDACTED]")
+	}
+	return redacted
+}
+
+// responseCacheKey keys a cached response by URL and API key, so a request sent with another key
+// through WithAPIKey never gets a response cached for a different account
+func responseCacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + " " + hex.EncodeToString(sum[:8])
+}
+
+// shouldRetry reports whether the request that caused the response can be retried, a rate limited
+// request was not handled so it is always safe to send again
+func shouldRetry(req *http.Request, r *http.Response) bool {
+	if r.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return r.StatusCode >= http.StatusInternalServerError && isIdempotent(req)
+}
 
-	return response, err
+// isIdempotent reports whether sending the request twice has the same effect as sending it once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(HeaderIdempotencyKey) != ""
+}
+
+// backoff returns how long to wait before the given retry attempt, Retry-After takes precedence when present
+func (rc RetryConfig) backoff(attempt int, rate Rate) time.Duration {
+	if rate.RetryAfter != nil {
+		return *rate.RetryAfter
+	}
+
+	wait := rc.MinWait << uint(attempt)
+	if wait < rc.MinWait || (rc.MaxWait > 0 && wait > rc.MaxWait) {
+		wait = rc.MaxWait
+	}
+
+	return wait
+}
+
+// sleep waits for the duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -225,20 +835,27 @@ func checkResponse(r *http.Response) error {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{Response: r, ContentType: r.Header.Get("Content-Type")}
 	data, err := io.ReadAll(r.Body)
 
 	if err == nil && len(data) > 0 {
 		err := json.Unmarshal(data, errorResponse)
 		if err != nil {
-			errorResponse.Message = string(data)
+			// not JSON, e.g. an HTML error page of a proxy
+			errorResponse.Message = truncateMessage(strings.TrimSpace(string(data)))
 		}
 	}
 
+	if errorResponse.Message == "" {
+		errorResponse.Message = http.StatusText(r.StatusCode)
+	}
+
 	switch {
 	case r.StatusCode == http.StatusUnauthorized:
 		return (*AuthError)(errorResponse)
-	case r.StatusCode == http.StatusTooManyRequests && r.Header.Get(HeaderRateRemaining) == "0":
+	case r.StatusCode == http.StatusNotFound:
+		return (*NotFoundError)(errorResponse)
+	case r.StatusCode == http.StatusTooManyRequests:
 		return &RateLimitError{
 			Rate:     parseRate(r),
 			Response: errorResponse.Response,
@@ -249,6 +866,68 @@ func checkResponse(r *http.Response) error {
 	}
 }
 
+// MaxLimit is the largest page size the list endpoints accept
+const MaxLimit = 1000
+
+// ListOptions - the paging options shared by every list, Page is used by offset paginated
+// lists and Cursor by cursor paginated ones. Zero values are not sent.
+type ListOptions struct {
+	Page   int    `url:"page,omitempty" json:"page,omitempty"`
+	Limit  int    `url:"limit,omitempty" json:"limit,omitempty"`
+	Cursor string `url:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// validateListOptions checks the page size before the request is sent, so it fails with a clear error instead of a 422
+func (o ListOptions) validateListOptions() error {
+	if o.Limit != 0 && (o.Limit < 1 || o.Limit > MaxLimit) {
+		return fmt.Errorf("limit must be between 1 and %d, got %d", MaxLimit, o.Limit)
+	}
+	return nil
+}
+
+// maxErrorMessageLength limits how much of a non-JSON error body ends up in the message
+const maxErrorMessageLength = 512
+
+// truncateMessage cuts the message at a character boundary, so a multi-byte character is never split
+func truncateMessage(message string) string {
+	if len(message) <= maxErrorMessageLength {
+		return message
+	}
+	end := maxErrorMessageLength
+	for end > 0 && !utf8.RuneStart(message[end]) {
+		end--
+	}
+	return message[:end] + "..."
+}
+
+// optionFilters returns the Filters field of an options struct
+func optionFilters(v reflect.Value) []Filter {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := v.FieldByName("Filters")
+	if !field.IsValid() {
+		return nil
+	}
+
+	filters, ok := field.Interface().(*[]Filter)
+	if !ok || filters == nil {
+		return nil
+	}
+
+	return *filters
+}
+
+// isNilPointer reports whether v is a typed nil pointer, e.g. nil list options
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// addOptions encodes the options as query params, Filters become filter[name]=value and slices tagged
+// with brackets e.g. `url:"groups,brackets"` are sent as repeated groups[] params
 func addOptions(s string, opt interface{}) (string, error) {
 	v := reflect.ValueOf(opt)
 
@@ -268,24 +947,20 @@ func addOptions(s string, opt interface{}) (string, error) {
 		return s, err
 	}
 
+	for _, filter := range optionFilters(v) {
+		origValues.Add(filter.key(), fmt.Sprint(filter.Value))
+	}
+
 	for k, v := range newValues {
+		// filters are added from the options directly, the query encoding loses their structure
 		if k == "Filters" {
-			for _, fv := range v {
-				if fv == "" {
-					continue
-				}
-				split := strings.Fields(strings.Trim(fv, "{}"))
-				filterKey := fmt.Sprintf("filter[%s]", split[0])
-				origValues.Add(filterKey, split[1])
-			}
 			continue
-		} else {
-			for _, fv := range v {
-				if fv == "" {
-					continue
-				}
-				origValues.Add(k, fv)
+		}
+		for _, fv := range v {
+			if fv == "" {
+				continue
 			}
+			origValues.Add(k, fv)
 		}
 	}
 
@@ -314,8 +989,16 @@ func parseRate(r *http.Response) Rate {
 	return rate
 }
 
-// RateLimitError occurs when MailerLite returns 403 Forbidden response with a rate limit
-// remaining value of 0.
+// TransportError occurs when the request did not get a response, e.g. a connection reset or a DNS failure
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string { return e.Err.Error() }
+
+func (e *TransportError) Unwrap() error { return e.Err }
+
+// RateLimitError occurs when MailerLite returns 429 Too Many Requests response.
 type RateLimitError struct {
 	Rate     Rate           // Rate specifies last known rate limit for the client
 	Response *http.Response // HTTP response that caused this error
@@ -328,6 +1011,53 @@ func (r *RateLimitError) Error() string {
 		r.Response.StatusCode, r.Message, r.Rate.RetryAfter)
 }
 
+// RetryAfter returns how long to wait before retrying, 0 when the API did not say
+func (r *RateLimitError) RetryAfter() time.Duration {
+	if r.Rate.RetryAfter == nil {
+		return 0
+	}
+	return *r.Rate.RetryAfter
+}
+
+// IsNotFound reports whether the error was caused by a missing resource
+func IsNotFound(err error) bool {
+	var notFoundError *NotFoundError
+	return errors.As(err, &notFoundError) || StatusCode(err) == http.StatusNotFound
+}
+
+// StatusCode returns the HTTP status code of an API error, -1 for any other error
+func StatusCode(err error) int {
+	var response *http.Response
+
+	var errorResponse *ErrorResponse
+	var authError *AuthError
+	var notFoundError *NotFoundError
+	var rateLimitError *RateLimitError
+
+	switch {
+	case errors.As(err, &errorResponse):
+		response = errorResponse.Response
+	case errors.As(err, &authError):
+		response = authError.Response
+	case errors.As(err, &notFoundError):
+		response = notFoundError.Response
+	case errors.As(err, &rateLimitError):
+		response = rateLimitError.Response
+	}
+
+	if response == nil {
+		return -1
+	}
+
+	return response.StatusCode
+}
+
+// IsRateLimited reports whether the error was caused by the API rate limit
+func IsRateLimited(err error) bool {
+	var rateLimitError *RateLimitError
+	return errors.As(err, &rateLimitError)
+}
+
 // Bool is a helper routine that allocates a new bool value
 // to store v and returns a pointer to it.
 func Bool(v bool) *bool { return &v }
@@ -2,13 +2,20 @@ package mailerlite_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mailerlite/mailerlite-go"
 	"github.com/stretchr/testify/assert"
@@ -234,7 +241,7 @@ func TestWillHandleAPIFilters(t *testing.T) {
 	subscribers, _, _ := client.Subscriber.List(ctx, listOptions)
 
 	assert.Equal(t, len(subscribers.Data), 1)
-	assert.Equal(t, subscribers.Data[0].Status, "active")
+	assert.Equal(t, subscribers.Data[0].Status, mailerlite.SubscriberStatusActive)
 }
 
 func TestWillHandleMultipleAPIFilters(t *testing.T) {
@@ -274,7 +281,7 @@ func TestWillHandleMultipleAPIFilters(t *testing.T) {
 	subscribers, _, _ := client.Subscriber.List(ctx, listOptions)
 
 	assert.Equal(t, len(subscribers.Data), 1)
-	assert.Equal(t, subscribers.Data[0].Status, "active")
+	assert.Equal(t, subscribers.Data[0].Status, mailerlite.SubscriberStatusActive)
 }
 
 func TestWillHandleAPIAuthError(t *testing.T) {
@@ -304,6 +311,92 @@ func TestWillHandleAPIAuthError(t *testing.T) {
 	assert.Equal(t, err.Error(), "GET https://connect.mailerlite.com/api/subscribers: 401 Unauthenticated. map[]")
 }
 
+func TestWillFallBackToNextAPIKeyOnAuthError(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var keys []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get("Authorization"))
+		if req.Header.Get("Authorization") == "Bearer old-key" {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Unauthenticated."}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": [{"id": "1", "email": "client@example.com"}]}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+	client.SetAPIKeys([]string{"old-key", "new-key"})
+
+	subscribers, _, err := client.Subscriber.List(ctx, &mailerlite.ListSubscriberOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(subscribers.Data))
+	assert.Equal(t, []string{"Bearer old-key", "Bearer new-key"}, keys)
+	assert.Equal(t, "new-key", client.APIKey())
+
+	keys = nil
+
+	_, _, err = client.Subscriber.List(ctx, &mailerlite.ListSubscriberOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer new-key"}, keys)
+
+	client.SetAPIKeys([]string{"old-key"})
+	keys = nil
+
+	_, _, err = client.Subscriber.List(ctx, &mailerlite.ListSubscriberOptions{})
+
+	assert.IsType(t, &mailerlite.AuthError{}, err)
+	assert.Equal(t, []string{"Bearer old-key"}, keys)
+}
+
+func TestWillNotCountAPIKeySwapAsRetry(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var keys []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get("Authorization"))
+		switch {
+		case req.Header.Get("Authorization") == "Bearer old-key":
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Unauthenticated."}`)),
+			}
+		case len(keys) == 2:
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Request:    req,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Service Unavailable."}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+	client.SetAPIKeys([]string{"old-key", "new-key"})
+	client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: 1, MinWait: time.Millisecond})
+
+	_, _, err := client.Subscriber.List(context.TODO(), &mailerlite.ListSubscriberOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer old-key", "Bearer new-key", "Bearer new-key"}, keys)
+}
+
 func TestWillHandleAPIRateError(t *testing.T) {
 	client := mailerlite.NewClient(testKey)
 
@@ -345,3 +438,1019 @@ func TestWillHandleAPIRateError(t *testing.T) {
 	assert.Equal(t, "GET https://connect.mailerlite.com/api/subscribers: 429 Too Many Attempts. [retry after 59s]", err.Error())
 
 }
+
+func TestWillRetryRateLimitedRequest(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	attempts := 0
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		attempts++
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"name":"Newsletter"}`, string(body))
+
+		if attempts == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Request:    req,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Too Many Attempts."}`)),
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Newsletter"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+	client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: 2, MinWait: time.Millisecond})
+
+	group, res, err := client.Group.Create(context.TODO(), &mailerlite.CreateGroupOptions{Name: "Newsletter"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "1", group.Data.ID)
+}
+
+func TestWillStopRetryingWhenContextIsCancelled(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	attempts := 0
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Request:    req,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Service Unavailable."}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+	client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: 3, MinWait: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Subscriber.List(ctx, &mailerlite.ListSubscriberOptions{})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWillHandleAPIRateErrorWithRemainingRequests(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	header := http.Header{}
+	header.Set(mailerlite.HeaderRateLimit, "120")
+	header.Set(mailerlite.HeaderRateRemaining, "3")
+	header.Set(mailerlite.HeaderRateRetryAfter, "30")
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Request:    req,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Too Many Attempts."}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Subscriber.List(context.TODO(), &mailerlite.ListSubscriberOptions{})
+
+	assert.True(t, mailerlite.IsRateLimited(err))
+	assert.False(t, mailerlite.IsRateLimited(fmt.Errorf("network down")))
+
+	if err, ok := err.(*mailerlite.RateLimitError); ok {
+		assert.Equal(t, 30*time.Second, err.RetryAfter())
+		assert.Equal(t, 3, err.Rate.Remaining)
+	} else {
+		assert.Fail(t, "expected a RateLimitError")
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestWillCloseResponseBodyAfterDecoding(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	body := &closeRecorder{Reader: strings.NewReader(`{"data": [{"id": "1", "name": "Europe/Vilnius"}]}`)}
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	timezones, _, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Vilnius", timezones.Data[0].Name)
+	assert.True(t, body.closed)
+}
+
+func TestCanReadErrorStatusCode(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	statusCode := http.StatusNotFound
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Error."}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Subscriber.GetByEmail(context.TODO(), "missing@example.com")
+
+	assert.True(t, mailerlite.IsNotFound(err))
+	assert.Equal(t, http.StatusNotFound, mailerlite.StatusCode(err))
+
+	statusCode = http.StatusUnauthorized
+
+	_, _, err = client.Subscriber.GetByEmail(context.TODO(), "missing@example.com")
+
+	assert.False(t, mailerlite.IsNotFound(err))
+	assert.Equal(t, http.StatusUnauthorized, mailerlite.StatusCode(err))
+
+	err = fmt.Errorf("connection refused")
+
+	assert.False(t, mailerlite.IsNotFound(err))
+	assert.Equal(t, -1, mailerlite.StatusCode(err))
+}
+
+func TestCanReadValidationFieldErrors(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusUnprocessableEntity,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"message": "The given data was invalid.",
+				"errors": {
+					"email": ["The email field is required.", "The email must be a valid email address."],
+					"groups.0": ["The selected groups.0 is invalid.", "The groups.0 must be a string."]
+				}
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Subscriber.Create(context.TODO(), &mailerlite.CreateSubscriberOptions{})
+
+	errorResponse, ok := err.(*mailerlite.ErrorResponse)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, []string{"The email field is required.", "The email must be a valid email address."}, errorResponse.FieldErrors("email"))
+	assert.Equal(t, 2, len(errorResponse.FieldErrors("groups.0")))
+	assert.Empty(t, errorResponse.FieldErrors("status"))
+}
+
+func TestCanSetAPIBase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/api/timezones", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data": [{"id": "1", "name": "Europe/Vilnius"}]}`))
+	}))
+	defer server.Close()
+
+	client := mailerlite.NewClient(testKey)
+
+	err := client.SetAPIBase(server.URL + "/proxy/api/")
+	assert.NoError(t, err)
+
+	timezones, _, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Vilnius", timezones.Data[0].Name)
+}
+
+func TestCanConfigureClientWithOptions(t *testing.T) {
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "https://proxy.example.com/api/timezones", req.URL.String())
+		assert.Equal(t, "my-app/1.0", req.Header.Get("User-Agent"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client := mailerlite.NewClient(testKey,
+		mailerlite.WithHTTPClient(testClient),
+		mailerlite.WithBaseURL("https://proxy.example.com/api"),
+		mailerlite.WithUserAgent("my-app/1.0"),
+		mailerlite.WithTimeout(5*time.Second),
+	)
+
+	assert.Equal(t, 5*time.Second, client.Client().Timeout)
+	assert.Equal(t, time.Duration(0), testClient.Timeout)
+
+	_, res, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestWillNotModifyDefaultClientTimeout(t *testing.T) {
+	client := mailerlite.NewClient(testKey, mailerlite.WithTimeout(time.Second))
+
+	assert.Equal(t, time.Second, client.Client().Timeout)
+	assert.Equal(t, time.Duration(0), http.DefaultClient.Timeout)
+}
+
+func TestCanSetCustomUserAgent(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	userAgent := fmt.Sprintf("my-app/1.0 go-mailerlite/%v", mailerlite.Version)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, userAgent, req.Header.Get("User-Agent"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+	client.SetUserAgent(userAgent)
+
+	_, _, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+}
+
+func TestWillNotSendBodyWithoutPayload(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Nil(t, req.Body)
+		assert.Equal(t, int64(0), req.ContentLength)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Subscriber.List(context.TODO(), &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 10}})
+	assert.NoError(t, err)
+
+	_, err = client.Subscriber.Delete(context.TODO(), "1234")
+	assert.NoError(t, err)
+}
+
+func TestCanLogRequestsWithRedactedAPIKey(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "Bearer "+testKey, req.Header.Get("Authorization"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	calls := 0
+	client.SetDebugLogger(func(req *http.Request, resp *http.Response, err error) {
+		calls++
+		assert.NoError(t, err)
+		assert.Equal(t, "https://connect.mailerlite.com/api/timezones", req.URL.String())
+		assert.Equal(t, "Bearer [REDACTED]", req.Header.Get("Authorization"))
+		assert.NotContains(t, req.Header.Get("Authorization"), testKey)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	_, _, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCanDecompressGzipResponses(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write([]byte(`{"data": [{"id": "1", "name": "Europe/Vilnius"}]}`))
+	_ = writer.Close()
+
+	body := &closeRecorder{Reader: &compressed}
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "gzip", req.Header.Get("Accept-Encoding"))
+
+		header := http.Header{}
+		header.Set("Content-Encoding", "gzip")
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Header:     header,
+			Body:       body,
+		}
+	})
+
+	client := mailerlite.NewClient(testKey, mailerlite.WithHTTPClient(testClient), mailerlite.WithCompression())
+
+	timezones, _, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Vilnius", timezones.Data[0].Name)
+	assert.True(t, body.closed)
+}
+
+func TestCanServeCachedResponseOnNotModified(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+	client.SetResponseCache(mailerlite.NewMemoryResponseCache())
+
+	calls := 0
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		if calls == 1 {
+			assert.Empty(t, req.Header.Get("If-None-Match"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": []string{`"v1"`}},
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Cached account"}}`)),
+			}
+		}
+
+		assert.Equal(t, `"v1"`, req.Header.Get("If-None-Match"))
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Body:       io.NopCloser(strings.NewReader(``)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	first, _, err := client.Account.Get(ctx)
+	assert.NoError(t, err)
+
+	second, res, err := client.Account.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Equal(t, first.Data.Name, second.Data.Name)
+	assert.Equal(t, "Cached account", second.Data.Name)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWillKeepCachedResponsesApartPerAPIKey(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+	client.SetResponseCache(mailerlite.NewMemoryResponseCache())
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Header.Get("Authorization") == "Bearer tenant-b" {
+			assert.Empty(t, req.Header.Get("If-None-Match"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": []string{`"v1"`}},
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "2", "name": "Tenant B"}}`)),
+			}
+		}
+		if req.Header.Get("If-None-Match") != "" {
+			return &http.Response{
+				StatusCode: http.StatusNotModified,
+				Body:       io.NopCloser(strings.NewReader(``)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": []string{`"v1"`}},
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Tenant A"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	tenantA := mailerlite.WithAPIKey(context.TODO(), "tenant-a")
+	tenantB := mailerlite.WithAPIKey(context.TODO(), "tenant-b")
+
+	first, _, err := client.Account.Get(tenantA)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tenant A", first.Data.Name)
+
+	second, _, err := client.Account.Get(tenantB)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tenant B", second.Data.Name)
+
+	cached, res, err := client.Account.Get(tenantA)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Equal(t, "Tenant A", cached.Data.Name)
+}
+
+func TestCanKeepRawResponseBody(t *testing.T) {
+	payload := `{"data": {"id": "1", "name": "Raw account", "unmodelled": true}}`
+	body := &closeRecorder{Reader: strings.NewReader(payload)}
+
+	client := mailerlite.NewClient(testKey, mailerlite.WithRawBody())
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	account, res, err := client.Account.Get(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Raw account", account.Data.Name)
+	assert.Equal(t, payload, string(res.RawBody))
+	assert.True(t, body.closed)
+}
+
+func TestCanSetLanguage(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "lt", req.Header.Get("Accept-Language"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+	client.SetLanguage("lt")
+
+	_, _, err := client.Timezone.List(context.TODO())
+
+	assert.NoError(t, err)
+}
+
+func TestWillTruncateErrorMessageAtCharacterBoundary(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Request:    req,
+			Header:     http.Header{"Content-Type": []string{"text/plain"}},
+			Body:       io.NopCloser(strings.NewReader("a" + strings.Repeat("é", 300))),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Timezone.List(context.TODO())
+
+	var errorResponse *mailerlite.ErrorResponse
+	assert.True(t, errors.As(err, &errorResponse))
+	assert.True(t, utf8.ValidString(errorResponse.Message))
+	assert.Equal(t, "a"+strings.Repeat("é", 255)+"...", errorResponse.Message)
+}
+
+func TestWillHandleNonJSONErrorBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		message     string
+		errors      map[string][]string
+	}{
+		{
+			name:        "html bad gateway",
+			status:      http.StatusBadGateway,
+			contentType: "text/html",
+			body:        "<html><body>" + strings.Repeat("Bad gateway ", 100) + "</body></html>",
+		},
+		{
+			name:    "empty internal server error",
+			status:  http.StatusInternalServerError,
+			message: "Internal Server Error",
+		},
+		{
+			name:        "json validation error",
+			status:      http.StatusUnprocessableEntity,
+			contentType: "application/json",
+			body:        `{"message": "The given data was invalid.", "errors": {"email": ["The email must be a valid email address."]}}`,
+			message:     "The given data was invalid.",
+			errors:      map[string][]string{"email": {"The email must be a valid email address."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mailerlite.NewClient(testKey)
+
+			testClient := NewTestClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: tt.status,
+					Request:    req,
+					Header:     http.Header{"Content-Type": []string{tt.contentType}},
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+				}
+			})
+
+			client.SetHttpClient(testClient)
+
+			_, _, err := client.Timezone.List(context.TODO())
+
+			var errorResponse *mailerlite.ErrorResponse
+			assert.True(t, errors.As(err, &errorResponse))
+			assert.Equal(t, tt.contentType, errorResponse.ContentType)
+			assert.Equal(t, tt.errors, errorResponse.Errors)
+
+			if tt.message != "" {
+				assert.Equal(t, tt.message, errorResponse.Message)
+			} else {
+				assert.True(t, strings.HasPrefix(errorResponse.Message, "<html><body>Bad gateway"))
+				assert.True(t, strings.HasSuffix(errorResponse.Message, "..."))
+				assert.Less(t, len(errorResponse.Message), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestCanCreateDefaultTransport(t *testing.T) {
+	transport := mailerlite.DefaultTransport()
+
+	assert.Equal(t, 20, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+	assert.NotSame(t, http.DefaultTransport, transport)
+}
+
+func TestWillDrainResponseBodiesForConnectionReuse(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var readers []*strings.Reader
+	var bodies []*closeRecorder
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		// the trailing whitespace is not read by the JSON decoder
+		reader := strings.NewReader(`{"data": [{"id": "1", "name": "Europe/Vilnius"}]}` + "\n\n")
+		body := &closeRecorder{Reader: reader}
+		readers = append(readers, reader)
+		bodies = append(bodies, body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := client.Timezone.List(context.TODO())
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, bodies, 3)
+	for i, body := range bodies {
+		assert.True(t, body.closed)
+		assert.Equal(t, 0, readers[i].Len())
+	}
+}
+
+// failingReader returns an error after the first read, so the error body is not fully read by checkResponse
+type failingReader struct {
+	*strings.Reader
+	reads int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads == 2 {
+		return 0, fmt.Errorf("connection reset")
+	}
+	return r.Reader.Read(p[:1])
+}
+
+func TestWillDrainAndCloseErrorResponseBody(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	reader := &failingReader{Reader: strings.NewReader(`{"message": "Server Error"}`)}
+	body := &closeRecorder{Reader: reader}
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Request:    req,
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Timezone.List(context.TODO())
+
+	assert.Error(t, err)
+	assert.True(t, body.closed)
+	assert.Equal(t, 0, reader.Len())
+}
+
+func TestCanStreamResponseBody(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	body := &closeRecorder{Reader: strings.NewReader("{\"id\": \"1\"}\n{\"id\": \"2\"}\n{\"id\": \"3\"}\n")}
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/export", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	req, err := client.NewRequest(http.MethodGet, "/subscribers/export", nil)
+	assert.NoError(t, err)
+
+	res, err := client.DoStream(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.False(t, body.closed)
+
+	var ids []string
+	decoder := json.NewDecoder(res.Body)
+	for decoder.More() {
+		var line struct {
+			ID string `json:"id"`
+		}
+		assert.NoError(t, decoder.Decode(&line))
+		ids = append(ids, line.ID)
+	}
+
+	assert.NoError(t, res.Body.Close())
+	assert.True(t, body.closed)
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestCanSetHeadersPerRequest(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "trace-1234", req.Header.Get("X-Trace-Id"))
+		assert.Equal(t, "tenant-a", req.Header.Get("X-Tenant"))
+		assert.Equal(t, "my-app/1.0", req.Header.Get("User-Agent"))
+		assert.Equal(t, "Bearer "+testKey, req.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	ctx := mailerlite.WithHeader(context.TODO(), "X-Trace-Id", "trace-1234")
+	ctx = mailerlite.WithHeader(ctx, "x-tenant", "tenant-a")
+	ctx = mailerlite.WithHeader(ctx, "User-Agent", "my-app/1.0")
+	ctx = mailerlite.WithHeader(ctx, "Authorization", "Bearer other-key")
+	ctx = mailerlite.WithHeader(ctx, "Content-Type", "text/plain")
+
+	_, _, err := client.Timezone.List(ctx)
+
+	assert.NoError(t, err)
+}
+
+type failingTransport func(req *http.Request) error
+
+func (f failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, f(req)
+}
+
+func TestWillWrapAndRetryTransportErrors(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	attempts := 0
+	client.SetHttpClient(&http.Client{Transport: failingTransport(func(req *http.Request) error {
+		attempts++
+		return syscall.ECONNRESET
+	})})
+	client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: 2, MinWait: time.Millisecond})
+
+	_, _, err := client.Timezone.List(context.TODO())
+
+	var transportErr *mailerlite.TransportError
+	assert.True(t, errors.As(err, &transportErr))
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+	assert.Equal(t, -1, mailerlite.StatusCode(err))
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWillNotRetryNonIdempotentRequests(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	attempts := 0
+	client.SetHttpClient(&http.Client{Transport: failingTransport(func(req *http.Request) error {
+		attempts++
+		return syscall.ECONNRESET
+	})})
+	client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: 2, MinWait: time.Millisecond})
+
+	_, _, err := client.Subscriber.Create(context.TODO(), &mailerlite.CreateSubscriberOptions{Email: "client@example.com"})
+
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	client.SetHttpClient(NewTestClient(func(req *http.Request) *http.Response {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Request:    req,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Bad Gateway"}`)),
+		}
+	}))
+
+	_, _, err = client.Subscriber.Create(context.TODO(), &mailerlite.CreateSubscriberOptions{Email: "client@example.com"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	ctx := mailerlite.WithIdempotencyKey(context.TODO(), "create-client")
+
+	_, _, err = client.Subscriber.Create(ctx, &mailerlite.CreateSubscriberOptions{Email: "client@example.com"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWillReportCancelledContextInsteadOfTransportError(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client.SetHttpClient(&http.Client{Transport: failingTransport(func(req *http.Request) error {
+		cancel()
+		return syscall.ECONNRESET
+	})})
+	client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: 2, MinWait: time.Millisecond})
+
+	_, _, err := client.Timezone.List(ctx)
+
+	var transportErr *mailerlite.TransportError
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, errors.As(err, &transportErr))
+}
+
+func TestWillRejectOutOfRangeLimit(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		t.Fatal("the request should not be sent")
+		return nil
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Subscriber.List(context.TODO(), &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 5000}})
+	assert.EqualError(t, err, "limit must be between 1 and 1000, got 5000")
+
+	_, _, err = client.Group.List(context.TODO(), &mailerlite.ListGroupOptions{ListOptions: mailerlite.ListOptions{Limit: -1}})
+	assert.EqualError(t, err, "limit must be between 1 and 1000, got -1")
+
+	options := &mailerlite.ListCampaignSubscriberOptions{CampaignID: "1", ListOptions: mailerlite.ListOptions{Limit: 1001}}
+	_, _, err = client.Campaign.Subscribers(context.TODO(), options)
+	assert.EqualError(t, err, "limit must be between 1 and 1000, got 1001")
+}
+
+func TestWillDrainAndCloseNoContentResponseBody(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	reader := strings.NewReader("\n")
+	body := &closeRecorder{Reader: reader}
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Subscriber.Delete(context.TODO(), "1234")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.True(t, body.closed)
+	assert.Equal(t, 0, reader.Len())
+}
+
+func TestWillEncodeArrayOptionsAsRepeatedParams(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	options := &struct {
+		Filters *[]mailerlite.Filter `json:"filters,omitempty"`
+		Groups  []string             `url:"groups,brackets"`
+		Limit   int                  `url:"limit,omitempty"`
+	}{
+		Filters: &[]mailerlite.Filter{{Name: "name", Value: "Acme Corp"}},
+		Groups:  []string{"1", "2"},
+		Limit:   10,
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "/subscribers", options)
+
+	assert.NoError(t, err)
+
+	query := req.URL.Query()
+	assert.Equal(t, []string{"1", "2"}, query["groups[]"])
+	assert.Equal(t, "Acme Corp", query.Get("filter[name]"))
+	assert.Equal(t, "10", query.Get("limit"))
+}
+
+func TestWillEncodePatchRequestBody(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	req, err := client.NewRequest(http.MethodPatch, "/subscribers/1", map[string]interface{}{"status": "active"})
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, req.Method)
+	assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1", req.URL.String())
+	assert.JSONEq(t, `{"status": "active"}`, string(body))
+}
+
+func TestCanChangeConfigurationWhileRequestsAreInFlight(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	client.SetHttpClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	}))
+
+	ctx := context.TODO()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			client.SetAPIKey(fmt.Sprintf("key-%d", i))
+			client.SetRetryConfig(mailerlite.RetryConfig{MaxRetries: i})
+			client.SetUserAgent(fmt.Sprintf("agent-%d", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Subscriber.List(ctx, &mailerlite.ListSubscriberOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWillEscapePathSegments(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var paths []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		paths = append(paths, req.URL.EscapedPath())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	_, err := client.Subscriber.AssignToGroup(ctx, "first/last@example.com", "1?2")
+	assert.NoError(t, err)
+	_, err = client.Webhook.Delete(ctx, "../account")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/api/subscribers/first%2Flast@example.com/groups/1%3F2",
+		"/api/webhooks/..%2Faccount",
+	}, paths)
+}
+
+func TestWillRejectRequestPathWithoutLeadingSlash(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	_, err := client.NewRequest(http.MethodGet, "subscribers", nil)
+
+	assert.EqualError(t, err, `request path "subscribers" must start with /`)
+}
+
+func TestCanUseMiddleware(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	var calls []string
+	composed := 0
+	counting := func(name string) mailerlite.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			composed++
+			return RoundTripFunc(func(req *http.Request) *http.Response {
+				calls = append(calls, name+" "+req.URL.Path)
+				res, _ := next.RoundTrip(req)
+				return res
+			})
+		}
+	}
+
+	client.Use(counting("outer"), counting("inner"))
+
+	_, _, err := client.Timezone.List(context.TODO())
+	assert.NoError(t, err)
+
+	_, _, err = client.Group.List(context.TODO(), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"outer /api/timezones",
+		"inner /api/timezones",
+		"outer /api/groups",
+		"inner /api/groups",
+	}, calls)
+	assert.Equal(t, 2, composed)
+}
+
+func TestCanUseAPIKeyFromContext(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	expected := "Bearer tenant-key"
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, expected, req.Header.Get("Authorization"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Timezone.List(mailerlite.WithAPIKey(context.TODO(), "tenant-key"))
+	assert.NoError(t, err)
+
+	expected = "Bearer " + testKey
+
+	_, _, err = client.Timezone.List(context.TODO())
+	assert.NoError(t, err)
+}
+
+func TestCanSetTimeoutBeforeHTTPClient(t *testing.T) {
+	testClient := &http.Client{}
+
+	client := mailerlite.NewClient(testKey,
+		mailerlite.WithTimeout(5*time.Second),
+		mailerlite.WithHTTPClient(testClient),
+	)
+
+	assert.Equal(t, 5*time.Second, client.Client().Timeout)
+	assert.Equal(t, time.Duration(0), testClient.Timeout)
+}
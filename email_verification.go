@@ -0,0 +1,81 @@
+package mailerlite
+
+import (
+	"context"
+	"net/http"
+)
+
+const emailVerificationEndpoint = "/email-verification"
+
+type EmailVerificationService service
+
+const (
+	EmailVerificationStatusDeliverable   = "deliverable"
+	EmailVerificationStatusUndeliverable = "undeliverable"
+	EmailVerificationStatusRisky         = "risky"
+)
+
+type rootEmailVerification struct {
+	Data EmailVerification `json:"data"`
+}
+
+// EmailVerification - the result of a single email verification, Status is empty while the job is still running
+type EmailVerification struct {
+	ID     string                  `json:"id,omitempty"`
+	Email  string                  `json:"email"`
+	Status string                  `json:"status"`
+	Checks EmailVerificationChecks `json:"checks"`
+}
+
+type EmailVerificationChecks struct {
+	Syntax     bool `json:"syntax"`
+	MX         bool `json:"mx"`
+	Disposable bool `json:"disposable"`
+	RoleBased  bool `json:"role_based"`
+	CatchAll   bool `json:"catch_all"`
+}
+
+// Pending reports whether the verification runs as a job that has to be polled for the result
+func (v *EmailVerification) Pending() bool {
+	return v.ID != "" && v.Status == ""
+}
+
+type verifyEmailOptions struct {
+	Email string `json:"email"`
+}
+
+// VerifyEmail - verify a single email address, when the result is pending use Poll with its ID
+func (s *EmailVerificationService) VerifyEmail(ctx context.Context, email string) (*rootEmailVerification, *Response, error) {
+	path := buildPath(emailVerificationEndpoint, "verify")
+
+	req, err := s.client.newRequest(http.MethodPost, path, &verifyEmailOptions{Email: email})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootEmailVerification)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+// Poll - get the result of a pending email verification job
+func (s *EmailVerificationService) Poll(ctx context.Context, verificationID string) (*rootEmailVerification, *Response, error) {
+	path := buildPath(emailVerificationEndpoint, verificationID)
+
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootEmailVerification)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
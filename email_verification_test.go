@@ -0,0 +1,78 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanVerifyEmail(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/email-verification/verify", req.URL.String())
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"email": "test@example.com"}`, string(body))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"email": "test@example.com",
+					"status": "risky",
+					"checks": {
+						"syntax": true,
+						"mx": true,
+						"disposable": false,
+						"role_based": false,
+						"catch_all": true
+					}
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	result, _, err := client.EmailVerification.VerifyEmail(ctx, "test@example.com")
+
+	assert.NoError(t, err)
+	assert.False(t, result.Data.Pending())
+	assert.Equal(t, mailerlite.EmailVerificationStatusRisky, result.Data.Status)
+	assert.True(t, result.Data.Checks.MX)
+	assert.True(t, result.Data.Checks.CatchAll)
+	assert.False(t, result.Data.Checks.Disposable)
+}
+
+func TestCanPollEmailVerification(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/email-verification/1234", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1234", "email": "test@example.com", "status": ""}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	result, _, err := client.EmailVerification.Poll(ctx, "1234")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Data.Pending())
+}
@@ -2,7 +2,6 @@ package mailerlite
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -30,11 +29,22 @@ type Field struct {
 // ListFieldOptions - modifies the behavior of FieldService.List method
 type ListFieldOptions struct {
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
-	Sort    string    `url:"sort,omitempty"`
+	ListOptions
+	Sort string `url:"sort,omitempty"`
 }
 
+// CreateFieldOptions - modifies the behavior of FieldService.Create method, Type is text, number or date
+type CreateFieldOptions struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// UpdateFieldOptions - modifies the behavior of FieldService.Update method
+type UpdateFieldOptions struct {
+	Name string `json:"name,omitempty"`
+}
+
+// List - list of fields
 func (s *FieldService) List(ctx context.Context, options *ListFieldOptions) (*rootFields, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, fieldEndpoint, options)
 	if err != nil {
@@ -50,12 +60,9 @@ func (s *FieldService) List(ctx context.Context, options *ListFieldOptions) (*ro
 	return root, res, nil
 }
 
-func (s *FieldService) Create(ctx context.Context, fieldName, fieldType string) (*rootField, *Response, error) {
-	body := map[string]interface{}{
-		"name": fieldName,
-		"type": fieldType,
-	}
-	req, err := s.client.newRequest(http.MethodPost, fieldEndpoint, body)
+// Create - create a new field of type text, number or date
+func (s *FieldService) Create(ctx context.Context, options *CreateFieldOptions) (*rootField, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, fieldEndpoint, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -69,11 +76,11 @@ func (s *FieldService) Create(ctx context.Context, fieldName, fieldType string)
 	return root, res, nil
 }
 
-func (s *FieldService) Update(ctx context.Context, fieldID, fieldName string) (*rootField, *Response, error) {
-	body := map[string]interface{}{"name": fieldName}
-	path := fmt.Sprintf("%s/%s", fieldEndpoint, fieldID)
+// Update - rename a field
+func (s *FieldService) Update(ctx context.Context, fieldID string, options *UpdateFieldOptions) (*rootField, *Response, error) {
+	path := buildPath(fieldEndpoint, fieldID)
 
-	req, err := s.client.newRequest(http.MethodPut, path, body)
+	req, err := s.client.newRequest(http.MethodPut, path, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -87,8 +94,9 @@ func (s *FieldService) Update(ctx context.Context, fieldID, fieldName string) (*
 	return root, res, nil
 }
 
+// Delete - delete a field
 func (s *FieldService) Delete(ctx context.Context, fieldID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", fieldEndpoint, fieldID)
+	path := buildPath(fieldEndpoint, fieldID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -0,0 +1,136 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListFields(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/fields?filter%5Btype%5D=text&limit=5", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{"id": "1", "name": "City", "key": "city", "type": "text"}]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListFieldOptions{
+		ListOptions: mailerlite.ListOptions{Limit: 5},
+		Filters:     &[]mailerlite.Filter{{Name: "type", Value: mailerlite.FieldTypeText}},
+	}
+
+	fields, _, err := client.Field.List(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "city", fields.Data[0].Key)
+	assert.Equal(t, "City", fields.Data[0].Name)
+	assert.Equal(t, "text", fields.Data[0].Type)
+}
+
+func TestCanCreateField(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/fields", req.URL.String())
+		assert.JSONEq(t, `{"name":"Birthday","type":"date"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "2", "name": "Birthday", "key": "birthday", "type": "date"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	field, _, err := client.Field.Create(ctx, &mailerlite.CreateFieldOptions{Name: "Birthday", Type: mailerlite.FieldTypeDate})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "birthday", field.Data.Key)
+}
+
+func TestCanUpdateField(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/fields/2", req.URL.String())
+		assert.JSONEq(t, `{"name":"Date of birth"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "2", "name": "Date of birth", "key": "birthday", "type": "date"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	field, _, err := client.Field.Update(ctx, "2", &mailerlite.UpdateFieldOptions{Name: "Date of birth"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Date of birth", field.Data.Name)
+}
+
+func TestCanDeleteField(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/fields/2", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Field.Delete(ctx, "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestWillNotSendNilOptionsAsBody(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Nil(t, req.Body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "2"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Field.Update(context.TODO(), "2", nil)
+
+	assert.NoError(t, err)
+}
@@ -1,11 +1,26 @@
 package mailerlite
 
+import (
+	"fmt"
+	"net/url"
+)
+
 // Filter is one of the arguments which has a name and a value
 type Filter struct {
 	// Name is the name of the field.
 	Name string `json:"name"`
 	// Value is the value which the entry should be filtered by.
 	Value interface{} `json:"value"`
+	// Operator is an optional comparison, it is sent as filter[name][operator].
+	Operator string `json:"operator,omitempty"`
+}
+
+// key returns the query key of the filter
+func (f Filter) key() string {
+	if f.Operator != "" {
+		return fmt.Sprintf("filter[%s][%s]", f.Name, f.Operator)
+	}
+	return fmt.Sprintf("filter[%s]", f.Name)
 }
 
 // NewFilter returns a new filter initialized with the given
@@ -16,3 +31,44 @@ func NewFilter(name string, value interface{}) *Filter {
 		Value: value,
 	}
 }
+
+// FilterBuilder builds the Filters of list options, e.g.
+// NewFilterBuilder().Where("status", "active").WhereOp("created_at", "gte", "2023-01-01").Build()
+type FilterBuilder struct {
+	filters []Filter
+}
+
+// NewFilterBuilder returns an empty filter builder
+func NewFilterBuilder() *FilterBuilder {
+	return &FilterBuilder{}
+}
+
+// Where adds an equality filter sent as filter[name]=value
+func (b *FilterBuilder) Where(name string, value interface{}) *FilterBuilder {
+	b.filters = append(b.filters, Filter{Name: name, Value: value})
+	return b
+}
+
+// WhereOp adds a filter with an operator sent as filter[name][operator]=value, for resources whose API supports it
+func (b *FilterBuilder) WhereOp(name, operator string, value interface{}) *FilterBuilder {
+	b.filters = append(b.filters, Filter{Name: name, Value: value, Operator: operator})
+	return b
+}
+
+// Build returns the filters in the form the list options expect
+func (b *FilterBuilder) Build() *[]Filter {
+	filters := make([]Filter, len(b.filters))
+	copy(filters, b.filters)
+	return &filters
+}
+
+// FieldFilters filters by custom field values, every entry is sent as filter[<field>]=<value>
+type FieldFilters map[string]string
+
+// EncodeValues implements query.Encoder so the map is encoded as nested filter params
+func (f FieldFilters) EncodeValues(_ string, v *url.Values) error {
+	for field, value := range f {
+		v.Add(fmt.Sprintf("filter[%s]", field), value)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package mailerlite_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanBuildSingleFilter(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	options := &mailerlite.ListSubscriberOptions{
+		Filters: mailerlite.NewFilterBuilder().Where("status", "active").Build(),
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "/subscribers", options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "filter%5Bstatus%5D=active", req.URL.RawQuery)
+}
+
+func TestCanBuildMultipleFiltersWithOperators(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	options := &mailerlite.ListCampaignOptions{
+		Filters: mailerlite.NewFilterBuilder().
+			Where("status", mailerlite.CampaignStatusSent).
+			Where("name", "Spring sale").
+			WhereOp("created_at", "gte", "2023-01-01").
+			Build(),
+		ListOptions: mailerlite.ListOptions{Limit: 10},
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "/campaigns", options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "filter%5Bcreated_at%5D%5Bgte%5D=2023-01-01&filter%5Bname%5D=Spring+sale&filter%5Bstatus%5D=sent&limit=10", req.URL.RawQuery)
+}
@@ -2,8 +2,8 @@ package mailerlite
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"time"
 )
 
 const formEndpoint = "/forms"
@@ -42,8 +42,8 @@ type Form struct {
 }
 
 type ConversionRate struct {
-	Float  int    `json:"float"`
-	String string `json:"string"`
+	Float  float64 `json:"float"`
+	String string  `json:"string"`
 }
 
 type Can struct {
@@ -54,21 +54,30 @@ type Can struct {
 type ListFormOptions struct {
 	Type    string    `url:"-"`
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
-	Sort    string    `url:"sort,omitempty"`
+	ListOptions
+	Sort string `url:"sort,omitempty"`
 }
 
 // ListFormSubscriberOptions - modifies the behavior of FormService.Subscribers method
 type ListFormSubscriberOptions struct {
 	FormID  string    `url:"-"`
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
+	ListOptions
+
+	// DateFrom and DateTo limit the signups to a range, they are sent in UTC as
+	// filter[created_at][gte] and filter[created_at][lte], a zero time is not sent
+	DateFrom time.Time `url:"-" json:"-"`
+	DateTo   time.Time `url:"-" json:"-"`
+}
+
+// UpdateFormOptions - modifies the behavior of FormService.Update method
+type UpdateFormOptions struct {
+	Name string `json:"name,omitempty"`
 }
 
+// List - list of forms of the given type: popup, embedded or promotion
 func (s *FormService) List(ctx context.Context, options *ListFormOptions) (*rootForms, *Response, error) {
-	path := fmt.Sprintf("%s/%s", formEndpoint, options.Type)
+	path := buildPath(formEndpoint, options.Type)
 	req, err := s.client.newRequest(http.MethodGet, path, options)
 	if err != nil {
 		return nil, nil, err
@@ -83,8 +92,9 @@ func (s *FormService) List(ctx context.Context, options *ListFormOptions) (*root
 	return root, res, nil
 }
 
+// Get - get a single form
 func (s *FormService) Get(ctx context.Context, formID string) (*rootForm, *Response, error) {
-	path := fmt.Sprintf("%s/%s", formEndpoint, formID)
+	path := buildPath(formEndpoint, formID)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -99,11 +109,11 @@ func (s *FormService) Get(ctx context.Context, formID string) (*rootForm, *Respo
 	return root, res, nil
 }
 
-func (s *FormService) Update(ctx context.Context, formID, formName string) (*rootForm, *Response, error) {
-	body := map[string]interface{}{"name": formName}
-	path := fmt.Sprintf("%s/%s", formEndpoint, formID)
+// Update - rename a form
+func (s *FormService) Update(ctx context.Context, formID string, options *UpdateFormOptions) (*rootForm, *Response, error) {
+	path := buildPath(formEndpoint, formID)
 
-	req, err := s.client.newRequest(http.MethodPut, path, body)
+	req, err := s.client.newRequest(http.MethodPut, path, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -117,8 +127,9 @@ func (s *FormService) Update(ctx context.Context, formID, formName string) (*roo
 	return root, res, nil
 }
 
+// Delete - delete a form
 func (s *FormService) Delete(ctx context.Context, formID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", formEndpoint, formID)
+	path := buildPath(formEndpoint, formID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -133,10 +144,26 @@ func (s *FormService) Delete(ctx context.Context, formID string) (*Response, err
 	return res, nil
 }
 
+// Subscribers - list subscribers who signed up through a form
 func (s *FormService) Subscribers(ctx context.Context, options *ListFormSubscriberOptions) (*rootSubscribers, *Response, error) {
-	path := fmt.Sprintf("%s/%s/subscribers", formEndpoint, options.FormID)
+	path := buildPath(formEndpoint, options.FormID, "subscribers")
 
-	req, err := s.client.newRequest(http.MethodGet, path, options)
+	listOptions := *options
+	var filters []Filter
+	if options.Filters != nil {
+		filters = append(filters, *options.Filters...)
+	}
+	if !options.DateFrom.IsZero() {
+		filters = append(filters, Filter{Name: "created_at", Operator: "gte", Value: options.DateFrom.UTC().Format(TimestampLayout)})
+	}
+	if !options.DateTo.IsZero() {
+		filters = append(filters, Filter{Name: "created_at", Operator: "lte", Value: options.DateTo.UTC().Format(TimestampLayout)})
+	}
+	if filters != nil {
+		listOptions.Filters = &filters
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, path, &listOptions)
 	if err != nil {
 		return nil, nil, err
 	}
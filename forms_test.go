@@ -0,0 +1,141 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListForms(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/forms/popup?limit=10&sort=-conversions_count", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{
+						"id": "1",
+						"type": "popup",
+						"name": "Sign up",
+						"conversions_count": 12,
+						"conversions_rate": {"float": 0.25, "string": "25%"},
+						"opens_count": 48
+					}
+				]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListFormOptions{
+		Type:        mailerlite.FormTypePopup,
+		ListOptions: mailerlite.ListOptions{Limit: 10},
+		Sort:        mailerlite.SortByConversionsCountDescending,
+	}
+
+	forms, _, err := client.Form.List(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, forms.Data[0].ConversionsCount)
+	assert.Equal(t, 0.25, forms.Data[0].ConversionsRate.Float)
+	assert.Equal(t, 48, forms.Data[0].OpensCount)
+}
+
+func TestCanListFormSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/forms/1/subscribers?filter%5Bstatus%5D=active", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{"id": "123456789", "email": "client@example.com", "status": "active"}]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListFormSubscriberOptions{
+		FormID:  "1",
+		Filters: &[]mailerlite.Filter{{Name: "status", Value: "active"}},
+	}
+
+	subscribers, _, err := client.Form.Subscribers(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789", subscribers.Data[0].ID)
+}
+
+func TestCanListFormSubscribersInDateRange(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		query := req.URL.Query()
+		assert.Equal(t, "active", query.Get("filter[status]"))
+		assert.Equal(t, "2023-05-01 08:00:00", query.Get("filter[created_at][gte]"))
+		assert.Equal(t, "2023-05-31 21:59:59", query.Get("filter[created_at][lte]"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": []}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	vilnius := time.FixedZone("EEST", 3*60*60)
+	options := &mailerlite.ListFormSubscriberOptions{
+		FormID:   "1",
+		Filters:  &[]mailerlite.Filter{{Name: "status", Value: "active"}},
+		DateFrom: time.Date(2023, 5, 1, 11, 0, 0, 0, vilnius),
+		DateTo:   time.Date(2023, 6, 1, 0, 59, 59, 0, vilnius),
+	}
+
+	_, _, err := client.Form.Subscribers(context.TODO(), options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*options.Filters))
+}
+
+func TestCanUpdateForm(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/forms/1", req.URL.String())
+		assert.JSONEq(t, `{"name":"Form Name"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Form Name"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	form, _, err := client.Form.Update(ctx, "1", &mailerlite.UpdateFormOptions{Name: "Form Name"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Form Name", form.Data.Name)
+}
@@ -36,21 +36,53 @@ type Group struct {
 	CreatedAt         string    `json:"created_at"`
 }
 
+// Stats - the engagement stats of the group, the API returns them as flat fields of the group.
+// Only sends, opens and clicks are filled, the unsubscribed, bounced and junk counts of a group are
+// subscriber statuses rather than email stats, so they stay on Group.
+func (g *Group) Stats() ResourceStats {
+	return ResourceStats{
+		Sent:        g.SentCount,
+		OpensCount:  g.OpensCount,
+		OpenRate:    g.OpenRate,
+		ClicksCount: g.ClicksCount,
+		ClickRate:   g.ClickRate,
+	}
+}
+
 // ListGroupOptions - modifies the behavior of GroupService.List method
 type ListGroupOptions struct {
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
-	Sort    string    `url:"sort,omitempty"`
+	ListOptions
+	Sort string `url:"sort,omitempty"`
 }
 
+// ListGroupSubscriberOptions - modifies the behavior of GroupService.Subscribers method
 type ListGroupSubscriberOptions struct {
 	GroupID string    `url:"-"`
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
+	ListOptions
 }
 
+// CreateGroupOptions - modifies the behavior of GroupService.Create method
+type CreateGroupOptions struct {
+	Name string `json:"name"`
+}
+
+// UpdateGroupOptions - modifies the behavior of GroupService.Update method
+type UpdateGroupOptions struct {
+	Name string `json:"name,omitempty"`
+}
+
+// GroupStatusUpdate - the outcome of GroupService.UpdateSubscribersStatus
+type GroupStatusUpdate struct {
+	Total      int
+	Successful int
+	Failed     int
+	// Errors holds an error for every batch that could not be sent
+	Errors []error
+}
+
+// List - list of groups
 func (s *GroupService) List(ctx context.Context, options *ListGroupOptions) (*rootGroups, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, groupEndpoint, options)
 	if err != nil {
@@ -66,9 +98,37 @@ func (s *GroupService) List(ctx context.Context, options *ListGroupOptions) (*ro
 	return root, res, nil
 }
 
-func (s *GroupService) Create(ctx context.Context, groupName string) (*rootGroup, *Response, error) {
-	body := map[string]interface{}{"name": groupName}
-	req, err := s.client.newRequest(http.MethodPost, groupEndpoint, body)
+// ListAll - list every group by fetching pages until the last one, the last response is returned
+func (s *GroupService) ListAll(ctx context.Context, options *ListGroupOptions) ([]Group, *Response, error) {
+	listOptions := ListGroupOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+	if listOptions.Page == 0 {
+		listOptions.Page = 1
+	}
+
+	var groups []Group
+	for {
+		root, res, err := s.List(ctx, &listOptions)
+		if err != nil {
+			return nil, res, err
+		}
+
+		groups = append(groups, root.Data...)
+
+		// meta.last_page, when present, caps the loop in case links keep pointing to a next page
+		lastPage := root.Meta.LastPage > 0 && listOptions.Page >= root.Meta.LastPage
+		if root.Links.IsLastPage() || len(root.Data) == 0 || lastPage {
+			return groups, res, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// Create - create a new group
+func (s *GroupService) Create(ctx context.Context, options *CreateGroupOptions) (*rootGroup, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, groupEndpoint, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -82,11 +142,11 @@ func (s *GroupService) Create(ctx context.Context, groupName string) (*rootGroup
 	return root, res, nil
 }
 
-func (s *GroupService) Update(ctx context.Context, groupID, groupName string) (*rootGroup, *Response, error) {
-	body := map[string]interface{}{"name": groupName}
-	path := fmt.Sprintf("%s/%s", groupEndpoint, groupID)
+// Update - rename a group
+func (s *GroupService) Update(ctx context.Context, groupID string, options *UpdateGroupOptions) (*rootGroup, *Response, error) {
+	path := buildPath(groupEndpoint, groupID)
 
-	req, err := s.client.newRequest(http.MethodPut, path, body)
+	req, err := s.client.newRequest(http.MethodPut, path, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,8 +160,9 @@ func (s *GroupService) Update(ctx context.Context, groupID, groupName string) (*
 	return root, res, nil
 }
 
+// Delete - delete a group
 func (s *GroupService) Delete(ctx context.Context, groupID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", groupEndpoint, groupID)
+	path := buildPath(groupEndpoint, groupID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -116,8 +177,9 @@ func (s *GroupService) Delete(ctx context.Context, groupID string) (*Response, e
 	return res, nil
 }
 
+// Subscribers - list subscribers belonging to a group, filter by status to narrow them down
 func (s *GroupService) Subscribers(ctx context.Context, options *ListGroupSubscriberOptions) (*rootSubscribers, *Response, error) {
-	path := fmt.Sprintf("%s/%s/subscribers", groupEndpoint, options.GroupID)
+	path := buildPath(groupEndpoint, options.GroupID, "subscribers")
 
 	req, err := s.client.newRequest(http.MethodGet, path, options)
 	if err != nil {
@@ -134,7 +196,7 @@ func (s *GroupService) Subscribers(ctx context.Context, options *ListGroupSubscr
 }
 
 func (s *GroupService) Assign(ctx context.Context, groupID, subscriberID string) (*rootGroup, *Response, error) {
-	path := fmt.Sprintf("%s/%s/groups/%s", subscriberEndpoint, subscriberID, groupID)
+	path := buildPath(subscriberEndpoint, subscriberID, "groups", groupID)
 
 	req, err := s.client.newRequest(http.MethodPost, path, nil)
 	if err != nil {
@@ -151,7 +213,7 @@ func (s *GroupService) Assign(ctx context.Context, groupID, subscriberID string)
 }
 
 func (s *GroupService) UnAssign(ctx context.Context, groupID, subscriberID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s/groups/%s", subscriberEndpoint, subscriberID, groupID)
+	path := buildPath(subscriberEndpoint, subscriberID, "groups", groupID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -165,3 +227,135 @@ func (s *GroupService) UnAssign(ctx context.Context, groupID, subscriberID strin
 
 	return res, nil
 }
+
+// UpdateSubscribersStatus - change the status of every subscriber in a group, e.g. to unsubscribe the whole group.
+// The updates are sent through the batch endpoint in chunks of MaxBatchRequests, the response of the last one is returned.
+func (s *GroupService) UpdateSubscribersStatus(ctx context.Context, groupID string, status SubscriberStatus) (*GroupStatusUpdate, *Response, error) {
+	if !status.IsValid() {
+		return nil, nil, fmt.Errorf("invalid subscriber status %q", status)
+	}
+
+	subscriberIDs, res, err := s.subscriberIDs(ctx, groupID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	requests := make([]BatchRequest, 0, len(subscriberIDs))
+	for _, subscriberID := range subscriberIDs {
+		requests = append(requests, BatchRequest{
+			Method: http.MethodPut,
+			Path:   "api" + buildPath(subscriberEndpoint, subscriberID),
+			Body:   map[string]interface{}{"status": status},
+		})
+	}
+
+	update := &GroupStatusUpdate{Total: len(requests)}
+	for start := 0; start < len(requests); start += MaxBatchRequests {
+		end := start + MaxBatchRequests
+		if end > len(requests) {
+			end = len(requests)
+		}
+
+		var batch *rootBatch
+		batch, res, err = s.client.Batch.Send(ctx, requests[start:end])
+		if err != nil {
+			update.Failed += end - start
+			update.Errors = append(update.Errors, err)
+			continue
+		}
+
+		update.Successful += batch.Successful
+		update.Failed += batch.Failed
+	}
+
+	return update, res, nil
+}
+
+// MoveResult - the outcome of GroupService.MoveSubscribers
+type MoveResult struct {
+	Total  int
+	Moved  int
+	Failed int
+	// Errors holds the reason a subscriber could not be moved by subscriber ID
+	Errors map[string]error
+}
+
+// MoveSubscribers - move every subscriber of a group to another group. Every subscriber is assigned to the
+// target group and removed from the source group through the batch endpoint, a subscriber that fails is
+// reported in MoveResult.Errors without stopping the others.
+func (s *GroupService) MoveSubscribers(ctx context.Context, fromGroupID, toGroupID string) (*MoveResult, *Response, error) {
+	// the ids are collected first, removing subscribers while paging would skip some of them
+	subscriberIDs, res, err := s.subscriberIDs(ctx, fromGroupID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	result := &MoveResult{Total: len(subscriberIDs), Errors: make(map[string]error)}
+
+	// every subscriber needs two requests, an assign and an unassign
+	chunkSize := MaxBatchRequests / 2
+	for start := 0; start < len(subscriberIDs); start += chunkSize {
+		end := start + chunkSize
+		if end > len(subscriberIDs) {
+			end = len(subscriberIDs)
+		}
+		chunk := subscriberIDs[start:end]
+
+		requests := make([]BatchRequest, 0, len(chunk)*2)
+		for _, subscriberID := range chunk {
+			requests = append(requests,
+				BatchRequest{Method: http.MethodPost, Path: "api" + buildPath(subscriberEndpoint, subscriberID, "groups", toGroupID)},
+				BatchRequest{Method: http.MethodDelete, Path: "api" + buildPath(subscriberEndpoint, subscriberID, "groups", fromGroupID)},
+			)
+		}
+
+		var batch *rootBatch
+		batch, res, err = s.client.Batch.Send(ctx, requests)
+		for i, subscriberID := range chunk {
+			switch {
+			case err != nil:
+				result.Errors[subscriberID] = err
+			case len(batch.Responses) < (i+1)*2:
+				result.Errors[subscriberID] = fmt.Errorf("missing batch response for subscriber %s", subscriberID)
+			default:
+				for _, response := range batch.Responses[i*2 : i*2+2] {
+					if response.Code >= http.StatusBadRequest {
+						result.Errors[subscriberID] = fmt.Errorf("batch request for subscriber %s failed with %d: %s", subscriberID, response.Code, response.Body)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	result.Failed = len(result.Errors)
+	result.Moved = result.Total - result.Failed
+
+	return result, res, nil
+}
+
+// subscriberIDs collects the ids of all subscribers of a group
+func (s *GroupService) subscriberIDs(ctx context.Context, groupID string) ([]string, *Response, error) {
+	var subscriberIDs []string
+
+	options := &ListGroupSubscriberOptions{GroupID: groupID, ListOptions: ListOptions{Limit: 1000}}
+	for {
+		root, res, err := s.Subscribers(ctx, options)
+		if err != nil {
+			return nil, res, err
+		}
+
+		for _, subscriber := range root.Data {
+			subscriberIDs = append(subscriberIDs, subscriber.ID)
+		}
+
+		cursor, err := root.Links.NextPageToken()
+		if err != nil {
+			return nil, res, err
+		}
+		if root.Links.IsLastPage() || cursor == "" {
+			return subscriberIDs, res, nil
+		}
+		options.Cursor = cursor
+	}
+}
@@ -0,0 +1,419 @@
+package mailerlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListGroups(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/groups?filter%5Bname%5D=News&limit=10&page=2&sort=-name", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{
+						"id": "1",
+						"name": "Newsletter",
+						"active_count": 10,
+						"open_rate": {"float": 0.5, "string": "50%"}
+					}
+				],
+				"links": {"next": "https://connect.mailerlite.com/api/groups?page=3"},
+				"meta": {"current_page": 2, "last_page": 3, "total": 21}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListGroupOptions{
+		ListOptions: mailerlite.ListOptions{Page: 2, Limit: 10},
+		Sort:        mailerlite.SortByNameDescending,
+		Filters:     &[]mailerlite.Filter{{Name: "name", Value: "News"}},
+	}
+
+	groups, _, err := client.Group.List(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(groups.Data))
+	assert.Equal(t, "Newsletter", groups.Data[0].Name)
+	assert.Equal(t, 0.5, groups.Data[0].OpenRate.Float)
+	assert.Equal(t, 21, groups.Meta.Total)
+	assert.False(t, groups.Links.IsLastPage())
+}
+
+func TestCanListAllGroups(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var pages []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+		assert.Equal(t, "25", req.URL.Query().Get("limit"))
+
+		body := `{
+			"data": [{"id": "1", "name": "First"}, {"id": "2", "name": "Second"}],
+			"links": {"next": "https://connect.mailerlite.com/api/groups?page=2"},
+			"meta": {"current_page": 1, "last_page": 2}
+		}`
+		if page == "2" {
+			// a next link on the last page must not keep the loop going
+			body = `{
+				"data": [{"id": "3", "name": "Third"}],
+				"links": {"next": "https://connect.mailerlite.com/api/groups?page=3"},
+				"meta": {"current_page": 2, "last_page": 2}
+			}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	groups, res, err := client.Group.ListAll(context.TODO(), &mailerlite.ListGroupOptions{ListOptions: mailerlite.ListOptions{Limit: 25}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, 3, len(groups))
+	for i, name := range []string{"First", "Second", "Third"} {
+		assert.Equal(t, name, groups[i].Name)
+	}
+	assert.Equal(t, "2", res.Request.URL.Query().Get("page"))
+}
+
+func TestCanListAllGroupsWithoutMeta(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body := `{"data": [{"id": "1", "name": "First"}], "links": {"next": "https://connect.mailerlite.com/api/groups?page=2"}}`
+		if req.URL.Query().Get("page") == "2" {
+			body = `{"data": [{"id": "2", "name": "Second"}], "links": {"next": null}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	groups, _, err := client.Group.ListAll(context.TODO(), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, "Second", groups[1].Name)
+}
+
+func TestCanCreateGroup(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/groups", req.URL.String())
+		assert.JSONEq(t, `{"name":"Newsletter"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Newsletter"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	group, _, err := client.Group.Create(ctx, &mailerlite.CreateGroupOptions{Name: "Newsletter"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", group.Data.ID)
+}
+
+func TestCanUpdateGroup(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/groups/1", req.URL.String())
+		assert.JSONEq(t, `{"name":"Weekly"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Weekly"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	group, _, err := client.Group.Update(ctx, "1", &mailerlite.UpdateGroupOptions{Name: "Weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Weekly", group.Data.Name)
+}
+
+func TestCanDeleteGroup(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/groups/1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Group.Delete(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestWillHandleGroupValidationError(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusUnprocessableEntity,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{"message": "The given data was invalid.",
+			"errors": {"name": ["The name field is required."]}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Group.Create(ctx, &mailerlite.CreateGroupOptions{})
+
+	assert.IsType(t, &mailerlite.ErrorResponse{}, err)
+	assert.Equal(t, "POST https://connect.mailerlite.com/api/groups: 422 The given data was invalid. map[name:[The name field is required.]]", err.Error())
+}
+
+func TestCanListGroupSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/groups/1/subscribers?filter%5Bstatus%5D=unsubscribed&limit=25", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{"id": "123456789", "email": "client@example.com", "status": "unsubscribed"}]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListGroupSubscriberOptions{
+		GroupID:     "1",
+		ListOptions: mailerlite.ListOptions{Limit: 25},
+		Filters:     &[]mailerlite.Filter{{Name: "status", Value: "unsubscribed"}},
+	}
+
+	subscribers, _, err := client.Group.Subscribers(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mailerlite.SubscriberStatusUnsubscribed, subscribers.Data[0].Status)
+}
+
+func TestCanUpdateGroupSubscribersStatusInBatches(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	subscribers := make([]string, 60)
+	for i := range subscribers {
+		subscribers[i] = fmt.Sprintf(`{"id": "%d"}`, i+1)
+	}
+
+	var batchSizes []int
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodGet {
+			assert.Equal(t, "/api/groups/1234/subscribers", req.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"data": [` + strings.Join(subscribers, ",") + `], "links": {"next": null}}`)),
+			}
+		}
+
+		assert.Equal(t, "https://connect.mailerlite.com/api/batch", req.URL.String())
+
+		var body struct {
+			Requests []mailerlite.BatchRequest `json:"requests"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, http.MethodPut, body.Requests[0].Method)
+		assert.Equal(t, map[string]interface{}{"status": "unsubscribed"}, body.Requests[0].Body)
+		batchSizes = append(batchSizes, len(body.Requests))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"total": %d, "successful": %d, "failed": 0}`, len(body.Requests), len(body.Requests)))),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	update, res, err := client.Group.UpdateSubscribersStatus(context.TODO(), "1234", "unsubscribed")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []int{50, 10}, batchSizes)
+	assert.Equal(t, 60, update.Total)
+	assert.Equal(t, 60, update.Successful)
+	assert.Equal(t, 0, update.Failed)
+	assert.Empty(t, update.Errors)
+}
+
+func TestCanReadGroupStats(t *testing.T) {
+	var group mailerlite.Group
+	err := json.Unmarshal([]byte(`{
+		"id": "1",
+		"sent_count": 50,
+		"opens_count": 25,
+		"open_rate": {"float": 0.5, "string": "50%"},
+		"clicks_count": 5,
+		"click_rate": {"float": 0.1, "string": "10%"},
+		"unsubscribed_count": 3,
+		"bounced_count": 1
+	}`), &group)
+
+	assert.NoError(t, err)
+
+	stats := group.Stats()
+	assert.Equal(t, 50, stats.Sent)
+	assert.Equal(t, 25, stats.OpensCount)
+	assert.Equal(t, "50%", stats.OpenRate.String)
+	assert.Equal(t, 0.1, stats.ClickRate.Float)
+	assert.Zero(t, stats.UnsubscribesCount)
+	assert.Zero(t, stats.HardBouncesCount)
+	assert.Equal(t, 3, group.UnsubscribedCount)
+	assert.Equal(t, 1, group.BouncedCount)
+}
+
+func TestCanMoveSubscribersBetweenGroups(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var batches [][]mailerlite.BatchRequest
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodGet {
+			assert.Equal(t, "/api/groups/from/subscribers", req.URL.Path)
+			body := `{"data": [{"id": "1"}, {"id": "2"}], "links": {"next": "https://connect.mailerlite.com/api/groups/from/subscribers?cursor=next"}}`
+			if req.URL.Query().Get("cursor") == "next" {
+				body = `{"data": [{"id": "3"}], "links": {"next": null}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}
+		}
+
+		var body struct {
+			Requests []mailerlite.BatchRequest `json:"requests"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		batches = append(batches, body.Requests)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"total": 6, "successful": 5, "failed": 1,
+				"responses": [
+					{"code": 200, "body": {}}, {"code": 204, "body": null},
+					{"code": 404, "body": {"message": "Not found"}}, {"code": 204, "body": null},
+					{"code": 200, "body": {}}, {"code": 204, "body": null}
+				]
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	result, _, err := client.Group.MoveSubscribers(context.TODO(), "from", "to")
+
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, mailerlite.BatchRequest{Method: http.MethodPost, Path: "api/subscribers/1/groups/to"}, batches[0][0])
+	assert.Equal(t, mailerlite.BatchRequest{Method: http.MethodDelete, Path: "api/subscribers/1/groups/from"}, batches[0][1])
+	assert.Equal(t, mailerlite.BatchRequest{Method: http.MethodPost, Path: "api/subscribers/3/groups/to"}, batches[0][4])
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 2, result.Moved)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Errors, "2")
+}
+
+func TestWillEscapeBatchPathSegments(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var requests []mailerlite.BatchRequest
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodGet {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"data": [{"id": "a/b"}], "links": {"next": null}}`)),
+			}
+		}
+
+		var body struct {
+			Requests []mailerlite.BatchRequest `json:"requests"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		requests = append(requests, body.Requests...)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"total": 2, "successful": 2, "failed": 0, "responses": [{"code": 200, "body": {}}, {"code": 204, "body": null}]}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Group.MoveSubscribers(context.TODO(), "from", "to?x")
+	assert.NoError(t, err)
+
+	_, _, err = client.Group.UpdateSubscribersStatus(context.TODO(), "from", mailerlite.SubscriberStatusActive)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"api/subscribers/a%2Fb/groups/to%3Fx",
+		"api/subscribers/a%2Fb/groups/from",
+		"api/subscribers/a%2Fb",
+	}, []string{requests[0].Path, requests[1].Path, requests[2].Path})
+}
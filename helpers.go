@@ -1,8 +1,11 @@
 package mailerlite
 
 import (
+	"encoding/json"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 var (
@@ -29,11 +32,21 @@ var (
 	SortByVisitors                     = "visitors"
 	SortByVisitorsDescending           = "-visitors"
 	SortByLastRegistrationAt           = "last_registration_at"
-	SortByLastRegistrationAtDescending = "-created_at"
+	SortByLastRegistrationAtDescending = "-last_registration_at"
 	SortByCreatedAt                    = "created_at"
 	SortByCreatedAtDescending          = "-created_at"
 	SortByUpdatedAt                    = "updated_at"
 	SortByUpdatedAtDescending          = "-updated_at"
+	SortBySubscribedAt                 = "subscribed_at"
+	SortBySubscribedAtDescending       = "-subscribed_at"
+	SortByEmail                        = "email"
+	SortByEmailDescending              = "-email"
+	SortByScheduledFor                 = "scheduled_for"
+	SortByScheduledForDescending       = "-scheduled_for"
+
+	FieldTypeText   = "text"
+	FieldTypeNumber = "number"
+	FieldTypeDate   = "date"
 
 	FormTypePopup     = "popup"
 	FormTypeEmbedded  = "embedded"
@@ -43,11 +56,148 @@ var (
 	CampaignTypeAB      = "ab"
 	CampaignTypeResend  = "resend"
 
+	CampaignStatusSent  = "sent"
+	CampaignStatusDraft = "draft"
+	CampaignStatusReady = "ready"
+
+	CampaignActivityOpened       = "opened"
+	CampaignActivityUnopened     = "unopened"
+	CampaignActivityClicked      = "clicked"
+	CampaignActivityUnsubscribed = "unsubscribed"
+	CampaignActivityForwarded    = "forwarded"
+	CampaignActivityHardBounced  = "hardbounced"
+	CampaignActivitySoftBounced  = "softbounced"
+	CampaignActivityJunk         = "junk"
+
 	CampaignScheduleTypeInstant   = "instant"
 	CampaignScheduleTypeScheduled = "scheduled"
 	CampaignScheduleTypeTimezone  = "timezone_based"
 )
 
+// sort fields accepted by each resource, the exported functions return copies so the lists cannot be modified
+var (
+	subscriberSortFields         = []string{SortByEmail, SortBySubscribedAt, SortByCreatedAt, SortByUpdatedAt}
+	groupSortFields              = []string{SortByName, SortByTotal, SortByOpenRate, SortByClickRate, SortByCreatedAt}
+	fieldSortFields              = []string{SortByName, SortByType}
+	formSortFields               = []string{SortByCreatedAt, SortByName, SortByConversionsCount, SortByOpensCount, SortByVisitors, SortByConversionRate, SortByLastRegistrationAt}
+	campaignSortFields           = []string{SortByName, SortByCreatedAt, SortByUpdatedAt, SortByScheduledFor}
+	campaignSubscriberSortFields = []string{SortByID, SortByUpdatedAt, SortByClicksCount, SortByOpensCount}
+)
+
+// SubscriberSortFields - the fields subscribers can be sorted by, use them with SortOption.ValidFor
+func SubscriberSortFields() []string {
+	return append([]string(nil), subscriberSortFields...)
+}
+
+// GroupSortFields - the fields groups can be sorted by
+func GroupSortFields() []string {
+	return append([]string(nil), groupSortFields...)
+}
+
+// FieldSortFields - the fields custom fields can be sorted by
+func FieldSortFields() []string {
+	return append([]string(nil), fieldSortFields...)
+}
+
+// FormSortFields - the fields forms can be sorted by
+func FormSortFields() []string {
+	return append([]string(nil), formSortFields...)
+}
+
+// CampaignSortFields - the fields campaigns can be sorted by
+func CampaignSortFields() []string {
+	return append([]string(nil), campaignSortFields...)
+}
+
+// CampaignSubscriberSortFields - the fields the subscriber activity of a campaign can be sorted by
+func CampaignSubscriberSortFields() []string {
+	return append([]string(nil), campaignSubscriberSortFields...)
+}
+
+// SortOption is a sort parameter, a field name prefixed with a minus for descending order
+type SortOption string
+
+// SortBy - composes a sort parameter for a field, descending sorts are prefixed with a minus
+func SortBy(field string, descending bool) SortOption {
+	if descending {
+		return SortOption("-" + field)
+	}
+	return SortOption(field)
+}
+
+// String returns the sort parameter, e.g. for the Sort field of the list options
+func (s SortOption) String() string {
+	return string(s)
+}
+
+// Field returns the field the option sorts by
+func (s SortOption) Field() string {
+	return strings.TrimPrefix(string(s), "-")
+}
+
+// Descending reports whether the option sorts in descending order
+func (s SortOption) Descending() bool {
+	return strings.HasPrefix(string(s), "-")
+}
+
+// ValidFor reports whether the option sorts by one of the given fields, e.g. GroupSortFields
+func (s SortOption) ValidFor(fields []string) bool {
+	field := s.Field()
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// TimestampLayout is the format MailerLite uses for timestamps
+const TimestampLayout = "2006-01-02 15:04:05"
+
+// Timestamp is a time that is encoded in the MailerLite format, null is decoded as the zero time.
+// omitempty has no effect on a struct, so optional fields use *Timestamp and are nil when null.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON decodes a MailerLite timestamp, RFC 3339 timestamps are accepted too
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(TimestampLayout, value)
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.Time = parsed
+
+	return nil
+}
+
+// MarshalJSON encodes the timestamp in the MailerLite format, the zero time is encoded as null
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(TimestampLayout))
+}
+
 type Meta struct {
 	// offset  based pagination
 	CurrentPage int         `json:"current_page"`
@@ -74,6 +224,38 @@ type Aggregations struct {
 	Draft int `json:"draft"`
 	Ready int `json:"ready"`
 	Sent  int `json:"sent"`
+
+	// Values holds every numeric aggregation by name, e.g. subscriber counts by status
+	Values map[string]int `json:"-"`
+}
+
+// UnmarshalJSON decodes the meta and keeps every numeric aggregation in Aggregations.Values
+func (m *Meta) UnmarshalJSON(data []byte) error {
+	type meta Meta
+	if err := json.Unmarshal(data, (*meta)(m)); err != nil {
+		return err
+	}
+
+	if m.Aggregations == nil {
+		return nil
+	}
+
+	var raw struct {
+		Aggregations map[string]json.RawMessage `json:"aggregations"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Aggregations.Values = make(map[string]int, len(raw.Aggregations))
+	for name, value := range raw.Aggregations {
+		var count int
+		if err := json.Unmarshal(value, &count); err == nil {
+			m.Aggregations.Values[name] = count
+		}
+	}
+
+	return nil
 }
 
 type Counts struct {
@@ -142,12 +324,12 @@ type ClickToOpenRate struct {
 	String string  `json:"string"`
 }
 
-// NextPageToken is the page token to request the next page of the list
+// NextPageToken is the page token (cursor) to request the next page of the list
 func (l *Links) NextPageToken() (string, error) {
 	return l.nextPageToken()
 }
 
-// PrevPageToken is the page token to request the previous page of the list
+// PrevPageToken is the page token (cursor) to request the previous page of the list
 func (l *Links) PrevPageToken() (string, error) {
 	return l.prevPageToken()
 }
@@ -183,7 +365,32 @@ func (l *Links) isLast() bool {
 	return l.Next == ""
 }
 
-func pageForURL(urlText string) (int, error) {
+// HasNextPage returns true if there is a page after the current one
+func (m *Meta) HasNextPage() bool {
+	return m.CurrentPage < m.TotalPages()
+}
+
+// NextPage returns the number of the next page, 0 when the current page is the last
+func (m *Meta) NextPage() int {
+	if !m.HasNextPage() {
+		return 0
+	}
+	return m.CurrentPage + 1
+}
+
+// TotalPages returns the number of pages of an offset based list
+func (m *Meta) TotalPages() int {
+	if m.LastPage > 0 {
+		return m.LastPage
+	}
+	if m.PerPage > 0 {
+		return (m.Total + m.PerPage - 1) / m.PerPage
+	}
+	return 0
+}
+
+// PageFromURL returns the page number of a pagination url
+func PageFromURL(urlText string) (int, error) {
 	u, err := url.ParseRequestURI(urlText)
 	if err != nil {
 		return 0, err
@@ -203,5 +410,8 @@ func pageTokenFromURL(urlText string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if cursor := u.Query().Get("cursor"); cursor != "" {
+		return cursor, nil
+	}
 	return u.Query().Get("page_token"), nil
 }
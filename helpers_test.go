@@ -0,0 +1,151 @@
+package mailerlite_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanNavigateOffsetPages(t *testing.T) {
+	first := mailerlite.Meta{CurrentPage: 1, LastPage: 3, PerPage: 10, Total: 25}
+	assert.True(t, first.HasNextPage())
+	assert.Equal(t, 2, first.NextPage())
+	assert.Equal(t, 3, first.TotalPages())
+
+	last := mailerlite.Meta{CurrentPage: 3, LastPage: 3, PerPage: 10, Total: 25}
+	assert.False(t, last.HasNextPage())
+	assert.Equal(t, 0, last.NextPage())
+
+	single := mailerlite.Meta{CurrentPage: 1, LastPage: 1, PerPage: 10, Total: 4}
+	assert.False(t, single.HasNextPage())
+	assert.Equal(t, 1, single.TotalPages())
+
+	withoutLastPage := mailerlite.Meta{CurrentPage: 1, PerPage: 10, Total: 25}
+	assert.Equal(t, 3, withoutLastPage.TotalPages())
+	assert.Equal(t, 2, withoutLastPage.NextPage())
+}
+
+func TestCanReadPageFromURL(t *testing.T) {
+	page, err := mailerlite.PageFromURL("https://connect.mailerlite.com/api/groups?page=4")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, page)
+
+	_, err = mailerlite.PageFromURL("https://connect.mailerlite.com/api/groups")
+	assert.Error(t, err)
+}
+
+func TestCanDecodeTimestamps(t *testing.T) {
+	var subscriber mailerlite.Subscriber
+
+	err := json.Unmarshal([]byte(`{
+		"created_at": "2021-01-01 12:00:00",
+		"updated_at": "2021-01-02T08:30:00Z",
+		"unsubscribed_at": null
+	}`), &subscriber)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), subscriber.CreatedAt.Time)
+	assert.Equal(t, time.Date(2021, 1, 2, 8, 30, 0, 0, time.UTC), subscriber.UpdatedAt.Time)
+	assert.Nil(t, subscriber.UnsubscribedAt)
+
+	encoded, err := json.Marshal(struct {
+		At       mailerlite.Timestamp  `json:"at"`
+		Empty    mailerlite.Timestamp  `json:"empty"`
+		Optional *mailerlite.Timestamp `json:"optional,omitempty"`
+	}{At: subscriber.CreatedAt})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"at": "2021-01-01 12:00:00", "empty": null}`, string(encoded))
+}
+
+func TestCanComposeSortOptions(t *testing.T) {
+	assert.Equal(t, mailerlite.SortOption("-name"), mailerlite.SortBy("name", true))
+	assert.Equal(t, "name", mailerlite.SortBy("name", false).String())
+
+	pairs := map[string]string{
+		mailerlite.SortByID:                 mailerlite.SortByIDDescending,
+		mailerlite.SortByName:               mailerlite.SortByNameDescending,
+		mailerlite.SortByOpenRate:           mailerlite.SortByOpenRateDescending,
+		mailerlite.SortByLastRegistrationAt: mailerlite.SortByLastRegistrationAtDescending,
+		mailerlite.SortByCreatedAt:          mailerlite.SortByCreatedAtDescending,
+		mailerlite.SortByUpdatedAt:          mailerlite.SortByUpdatedAtDescending,
+	}
+	for ascending, descending := range pairs {
+		assert.Equal(t, descending, mailerlite.SortBy(ascending, true).String())
+		assert.Equal(t, ascending, mailerlite.SortOption(descending).Field())
+		assert.True(t, mailerlite.SortOption(descending).Descending())
+		assert.False(t, mailerlite.SortOption(ascending).Descending())
+	}
+
+	assert.True(t, mailerlite.SortOption(mailerlite.SortByOpenRateDescending).ValidFor(mailerlite.GroupSortFields()))
+	assert.False(t, mailerlite.SortOption("-openrate").ValidFor(mailerlite.GroupSortFields()))
+	assert.False(t, mailerlite.SortOption(mailerlite.SortByVisitors).ValidFor(mailerlite.GroupSortFields()))
+
+	fields := mailerlite.GroupSortFields()
+	fields[0] = mailerlite.SortByVisitors
+	assert.Equal(t, mailerlite.SortByName, mailerlite.GroupSortFields()[0])
+}
+
+func TestSortConstantsHaveDescendingPairs(t *testing.T) {
+	pairs := [][2]string{
+		{mailerlite.SortByID, mailerlite.SortByIDDescending},
+		{mailerlite.SortByName, mailerlite.SortByNameDescending},
+		{mailerlite.SortByType, mailerlite.SortByTypeDescending},
+		{mailerlite.SortByTotal, mailerlite.SortByTotalDescending},
+		{mailerlite.SortByOpenRate, mailerlite.SortByOpenRateDescending},
+		{mailerlite.SortByClickRate, mailerlite.SortByClickRateDescending},
+		{mailerlite.SortByConversionsCount, mailerlite.SortByConversionsCountDescending},
+		{mailerlite.SortByConversionRate, mailerlite.SortByConversionRateDescending},
+		{mailerlite.SortByClicksCount, mailerlite.SortByClicksCountDescending},
+		{mailerlite.SortByOpensCount, mailerlite.SortByOpensCountDescending},
+		{mailerlite.SortByVisitors, mailerlite.SortByVisitorsDescending},
+		{mailerlite.SortByLastRegistrationAt, mailerlite.SortByLastRegistrationAtDescending},
+		{mailerlite.SortByCreatedAt, mailerlite.SortByCreatedAtDescending},
+		{mailerlite.SortByUpdatedAt, mailerlite.SortByUpdatedAtDescending},
+		{mailerlite.SortBySubscribedAt, mailerlite.SortBySubscribedAtDescending},
+		{mailerlite.SortByEmail, mailerlite.SortByEmailDescending},
+		{mailerlite.SortByScheduledFor, mailerlite.SortByScheduledForDescending},
+	}
+
+	known := map[string]bool{}
+	for _, pair := range pairs {
+		assert.Equal(t, pair[1], mailerlite.SortBy(pair[0], true).String())
+		known[pair[0]] = true
+	}
+
+	resources := [][]string{
+		mailerlite.SubscriberSortFields(),
+		mailerlite.GroupSortFields(),
+		mailerlite.FieldSortFields(),
+		mailerlite.FormSortFields(),
+		mailerlite.CampaignSortFields(),
+		mailerlite.CampaignSubscriberSortFields(),
+	}
+	for _, fields := range resources {
+		for _, field := range fields {
+			assert.True(t, known[field], field)
+		}
+	}
+}
+
+func TestCanDecodeMetaAggregations(t *testing.T) {
+	var meta mailerlite.Meta
+	err := json.Unmarshal([]byte(`{
+		"current_page": 1,
+		"total": 12,
+		"aggregations": {"total": 12, "active": 9, "unsubscribed": 2, "bounced": 1, "label": "status"}
+	}`), &meta)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, meta.CurrentPage)
+	assert.Equal(t, 12, meta.Total)
+	assert.Equal(t, 12, meta.Aggregations.Total)
+	assert.Equal(t, map[string]int{"total": 12, "active": 9, "unsubscribed": 2, "bounced": 1}, meta.Aggregations.Values)
+
+	meta = mailerlite.Meta{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"total": 3}`), &meta))
+	assert.Nil(t, meta.Aggregations)
+}
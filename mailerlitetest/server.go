@@ -0,0 +1,97 @@
+// Package mailerlitetest provides a fake MailerLite API for testing code that uses the mailerlite client.
+package mailerlitetest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/mailerlite/mailerlite-go"
+)
+
+const testAPIKey = "mailerlitetest-api-key"
+
+// Server is a fake MailerLite API that serves queued responses per path
+type Server struct {
+	*httptest.Server
+
+	// Client is a mailerlite client that sends its requests to the server
+	Client *mailerlite.Client
+
+	mu        sync.Mutex
+	responses map[string][]response
+	requests  []recordedRequest
+}
+
+type recordedRequest struct {
+	req  *http.Request
+	body []byte
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+// NewServer - starts a fake MailerLite API, call Close when the test is done
+func NewServer() *Server {
+	s := &Server{responses: make(map[string][]response)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	s.Client = mailerlite.NewClient(testAPIKey, mailerlite.WithHTTPClient(s.Server.Client()))
+	_ = s.Client.SetAPIBase(s.Server.URL)
+
+	return s
+}
+
+// Queue - queue a JSON response for a path like "/subscribers", queued responses are served in order
+func (s *Server) Queue(path string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[path] = append(s.responses[path], response{status: status, body: body})
+}
+
+// Requests - copies of the requests the server received so far, each with its body readable from the start
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]*http.Request, len(s.requests))
+	for i, recorded := range s.requests {
+		req := recorded.req.Clone(context.Background())
+		req.Body = io.NopCloser(bytes.NewReader(recorded.body))
+		requests[i] = req
+	}
+	return requests
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// the server closes the request body once the handler returns, so it is buffered for Requests
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, recordedRequest{req: r.Clone(context.Background()), body: body})
+	queued := s.responses[r.URL.Path]
+	var next *response
+	if len(queued) > 0 {
+		next = &queued[0]
+		s.responses[r.URL.Path] = queued[1:]
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if next == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"message": "no response queued for %s"}`, r.URL.Path)
+		return
+	}
+
+	w.WriteHeader(next.status)
+	fmt.Fprint(w, next.body)
+}
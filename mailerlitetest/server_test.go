@@ -0,0 +1,69 @@
+package mailerlitetest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/mailerlite/mailerlite-go/mailerlitetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanServeQueuedSubscriberList(t *testing.T) {
+	server := mailerlitetest.NewServer()
+	defer server.Close()
+
+	server.Queue("/subscribers", http.StatusOK, `{
+		"data": [
+			{"id": "1", "email": "first@example.com", "status": "active"},
+			{"id": "2", "email": "second@example.com", "status": "unsubscribed"}
+		],
+		"meta": {"per_page": 25}
+	}`)
+
+	subscribers, _, err := server.Client.Subscriber.List(context.TODO(), &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 25}})
+
+	assert.NoError(t, err)
+	assert.Len(t, subscribers.Data, 2)
+	assert.Equal(t, "second@example.com", subscribers.Data[1].Email)
+
+	requests := server.Requests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "25", requests[0].URL.Query().Get("limit"))
+}
+
+func TestWillReturnNotFoundWithoutQueuedResponse(t *testing.T) {
+	server := mailerlitetest.NewServer()
+	defer server.Close()
+
+	server.Queue("/subscribers/1", http.StatusOK, `{"data": {"id": "1"}}`)
+
+	ctx := context.TODO()
+
+	_, _, err := server.Client.Subscriber.Get(ctx, &mailerlite.GetSubscriberOptions{SubscriberID: "1"})
+	assert.NoError(t, err)
+
+	_, _, err = server.Client.Subscriber.Get(ctx, &mailerlite.GetSubscriberOptions{SubscriberID: "1"})
+	assert.True(t, mailerlite.IsNotFound(err))
+}
+
+func TestCanReadRecordedRequestBody(t *testing.T) {
+	server := mailerlitetest.NewServer()
+	defer server.Close()
+
+	server.Queue("/subscribers", http.StatusCreated, `{"data": {"id": "1", "email": "client@example.com"}}`)
+
+	_, _, err := server.Client.Subscriber.Create(context.TODO(), &mailerlite.CreateSubscriberOptions{Email: "client@example.com"})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		requests := server.Requests()
+		assert.Len(t, requests, 1)
+
+		body, err := io.ReadAll(requests[0].Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"email": "client@example.com"}`, string(body))
+	}
+}
@@ -0,0 +1,163 @@
+package mailerlite
+
+import "context"
+
+// Paginated is implemented by every list response
+type Paginated interface {
+	GetMeta() *Meta
+	GetLinks() *Links
+}
+
+// PageRequest - describes the page PageThrough wants to fetch, Cursor is set for cursor paginated
+// endpoints and Page for the others, both are empty for the first page
+type PageRequest struct {
+	Page   int
+	Cursor string
+}
+
+// PageThrough - fetch every page of a list and return all items. fetch loads the requested page,
+// usually by calling a List method, and returns its items together with the list response.
+func PageThrough[T any](ctx context.Context, fetch func(ctx context.Context, page PageRequest) ([]T, Paginated, error)) ([]T, error) {
+	var items []T
+	var next PageRequest
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return items, err
+		}
+
+		data, page, err := fetch(ctx, next)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, data...)
+
+		var done bool
+		next, done, err = nextPageRequest(next, len(data), page)
+		if err != nil || done {
+			return items, err
+		}
+	}
+}
+
+// nextPageRequest - the request for the page after the current one, done is true when it was the last page.
+// A cursor in the next link wins, then the page numbers of the meta, and a next link without either
+// increments the current page.
+func nextPageRequest(current PageRequest, count int, page Paginated) (PageRequest, bool, error) {
+	// an empty page means the list is exhausted even when a next link is present
+	if count == 0 {
+		return PageRequest{}, true, nil
+	}
+
+	links := page.GetLinks()
+	cursor, err := links.NextPageToken()
+	if err != nil {
+		return PageRequest{}, false, err
+	}
+
+	switch meta := page.GetMeta(); {
+	case cursor != "":
+		return PageRequest{Cursor: cursor}, false, nil
+	case meta.HasNextPage():
+		return PageRequest{Page: meta.NextPage()}, false, nil
+	case !links.IsLastPage():
+		if current.Page == 0 {
+			current.Page = 1
+		}
+		return PageRequest{Page: current.Page + 1}, false, nil
+	default:
+		return PageRequest{}, true, nil
+	}
+}
+
+// pageIterator - fetches the pages of a list lazily while iterating, the list iterators of the services are built on it
+type pageIterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page PageRequest) ([]T, Paginated, error)
+
+	next    PageRequest
+	page    []T
+	index   int
+	current *T
+	done    bool
+	err     error
+}
+
+func (it *pageIterator[T]) advance() bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.load()
+	}
+
+	it.current = &it.page[it.index]
+	it.index++
+
+	return true
+}
+
+func (it *pageIterator[T]) load() {
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return
+	}
+
+	data, page, err := it.fetch(it.ctx, it.next)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = data
+	it.index = 0
+	it.next, it.done, it.err = nextPageRequest(it.next, len(data), page)
+}
+
+func (r *rootSubscribers) GetMeta() *Meta   { return &r.Meta }
+func (r *rootSubscribers) GetLinks() *Links { return &r.Links }
+
+func (r *rootActivities) GetMeta() *Meta   { return &r.Meta }
+func (r *rootActivities) GetLinks() *Links { return &r.Links }
+
+func (r *rootGroups) GetMeta() *Meta   { return &r.Meta }
+func (r *rootGroups) GetLinks() *Links { return &r.Links }
+
+func (r *rootFields) GetMeta() *Meta   { return &r.Meta }
+func (r *rootFields) GetLinks() *Links { return &r.Links }
+
+func (r *rootForms) GetMeta() *Meta   { return &r.Meta }
+func (r *rootForms) GetLinks() *Links { return &r.Links }
+
+func (r *rootSegments) GetMeta() *Meta   { return &r.Meta }
+func (r *rootSegments) GetLinks() *Links { return &r.Links }
+
+func (r *rootWebhooks) GetMeta() *Meta   { return &r.Meta }
+func (r *rootWebhooks) GetLinks() *Links { return &r.Links }
+
+func (r *rootCampaigns) GetMeta() *Meta   { return &r.Meta }
+func (r *rootCampaigns) GetLinks() *Links { return &r.Links }
+
+func (r *rootCampaignSubscribers) GetMeta() *Meta   { return &r.Meta }
+func (r *rootCampaignSubscribers) GetLinks() *Links { return &r.Links }
+
+func (r *rootAutomations) GetMeta() *Meta   { return &r.Meta }
+func (r *rootAutomations) GetLinks() *Links { return &r.Links }
+
+func (r *rootAutomationsSubscriber) GetMeta() *Meta   { return &r.Meta }
+func (r *rootAutomationsSubscriber) GetLinks() *Links { return &r.Links }
+
+// IsEmpty reports whether a list response contains no records, e.g. when a filter matches nothing
+func (r *rootSubscribers) IsEmpty() bool           { return len(r.Data) == 0 }
+func (r *rootActivities) IsEmpty() bool            { return len(r.Data) == 0 }
+func (r *rootGroups) IsEmpty() bool                { return len(r.Data) == 0 }
+func (r *rootFields) IsEmpty() bool                { return len(r.Data) == 0 }
+func (r *rootForms) IsEmpty() bool                 { return len(r.Data) == 0 }
+func (r *rootSegments) IsEmpty() bool              { return len(r.Data) == 0 }
+func (r *rootWebhooks) IsEmpty() bool              { return len(r.Data) == 0 }
+func (r *rootCampaigns) IsEmpty() bool             { return len(r.Data) == 0 }
+func (r *rootCampaignSubscribers) IsEmpty() bool   { return len(r.Data) == 0 }
+func (r *rootAutomations) IsEmpty() bool           { return len(r.Data) == 0 }
+func (r *rootAutomationsSubscriber) IsEmpty() bool { return len(r.Data) == 0 }
+func (r *rootCampaignLanguages) IsEmpty() bool     { return len(r.Data) == 0 }
+func (r *rootTimezones) IsEmpty() bool             { return len(r.Data) == 0 }
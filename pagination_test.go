@@ -0,0 +1,85 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanPageThroughSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body := `{
+			"data": [{"id": "1"}, {"id": "2"}],
+			"links": {"next": "https://connect.mailerlite.com/api/subscribers?cursor=abc"},
+			"meta": {"per_page": 2}
+		}`
+		if req.URL.Query().Get("cursor") == "abc" {
+			body = `{
+				"data": [{"id": "3"}],
+				"links": {"next": null},
+				"meta": {"per_page": 2}
+			}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 2}}
+	subscribers, err := mailerlite.PageThrough(context.TODO(), func(ctx context.Context, page mailerlite.PageRequest) ([]mailerlite.Subscriber, mailerlite.Paginated, error) {
+		options.Cursor = page.Cursor
+		root, _, err := client.Subscriber.List(ctx, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		return root.Data, root, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, subscribers, 3)
+	assert.Equal(t, "3", subscribers[2].ID)
+}
+
+func TestCanPageThroughGroupsByPageNumber(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var pages []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+		body := `{"data": [{"id": "1"}], "meta": {"current_page": 1, "last_page": 2}}`
+		if page == "2" {
+			body = `{"data": [{"id": "2"}], "meta": {"current_page": 2, "last_page": 2}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	groups, err := mailerlite.PageThrough(context.TODO(), func(ctx context.Context, page mailerlite.PageRequest) ([]mailerlite.Group, mailerlite.Paginated, error) {
+		root, _, err := client.Group.List(ctx, &mailerlite.ListGroupOptions{ListOptions: mailerlite.ListOptions{Page: page.Page}})
+		if err != nil {
+			return nil, nil, err
+		}
+		return root.Data, root, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, []string{"", "2"}, pages)
+}
@@ -31,18 +31,23 @@ type Segment struct {
 
 // ListSegmentOptions - modifies the behavior of SegmentService.List method
 type ListSegmentOptions struct {
-	Page  int `url:"page,omitempty"`
-	Limit int `url:"limit,omitempty"`
+	ListOptions
 }
 
 // ListSegmentSubscriberOptions - modifies the behavior of SegmentService.Subscribers method
 type ListSegmentSubscriberOptions struct {
 	SegmentID string    `url:"-"`
 	Filters   *[]Filter `json:"filters,omitempty"`
-	Limit     int       `url:"limit,omitempty"`
-	After     int       `url:"after,omitempty"`
+	ListOptions
+	After int `url:"after,omitempty"`
 }
 
+// UpdateSegmentOptions - modifies the behavior of SegmentService.Update method
+type UpdateSegmentOptions struct {
+	Name string `json:"name,omitempty"`
+}
+
+// List - list of segments
 func (s *SegmentService) List(ctx context.Context, options *ListSegmentOptions) (*rootSegments, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, segmentEndpoint, options)
 	if err != nil {
@@ -58,11 +63,45 @@ func (s *SegmentService) List(ctx context.Context, options *ListSegmentOptions)
 	return root, res, nil
 }
 
-func (s *SegmentService) Update(ctx context.Context, segmentID, segmentName string) (*rootSegment, *Response, error) {
-	body := map[string]interface{}{"name": segmentName}
-	path := fmt.Sprintf("%s/%s", segmentEndpoint, segmentID)
+// Get - get a single segment with its total and stats, the API has no single segment endpoint
+// so the segments are listed until the one with the ID is found
+func (s *SegmentService) Get(ctx context.Context, segmentID string) (*rootSegment, *Response, error) {
+	options := &ListSegmentOptions{ListOptions: ListOptions{Page: 1, Limit: 250}}
+	for {
+		root, res, err := s.List(ctx, options)
+		if err != nil {
+			return nil, res, err
+		}
+
+		for _, segment := range root.Data {
+			if segment.ID == segmentID {
+				return &rootSegment{Data: segment}, res, nil
+			}
+		}
+
+		if len(root.Data) == 0 || isLastSegmentPage(root, options.Page) {
+			return nil, res, &NotFoundError{
+				Response: &http.Response{StatusCode: http.StatusNotFound, Request: res.Request},
+				Message:  fmt.Sprintf("segment %s not found", segmentID),
+			}
+		}
+		options.Page++
+	}
+}
+
+// isLastSegmentPage - the meta last page decides when the API sends it, the links otherwise
+func isLastSegmentPage(root *rootSegments, page int) bool {
+	if root.Meta.LastPage > 0 {
+		return page >= root.Meta.LastPage
+	}
+	return root.Links.IsLastPage()
+}
+
+// Update - rename a segment
+func (s *SegmentService) Update(ctx context.Context, segmentID string, options *UpdateSegmentOptions) (*rootSegment, *Response, error) {
+	path := buildPath(segmentEndpoint, segmentID)
 
-	req, err := s.client.newRequest(http.MethodPut, path, body)
+	req, err := s.client.newRequest(http.MethodPut, path, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,8 +115,9 @@ func (s *SegmentService) Update(ctx context.Context, segmentID, segmentName stri
 	return root, res, nil
 }
 
+// Delete - delete a segment
 func (s *SegmentService) Delete(ctx context.Context, segmentID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", segmentEndpoint, segmentID)
+	path := buildPath(segmentEndpoint, segmentID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -92,8 +132,9 @@ func (s *SegmentService) Delete(ctx context.Context, segmentID string) (*Respons
 	return res, nil
 }
 
+// Subscribers - list subscribers belonging to a segment
 func (s *SegmentService) Subscribers(ctx context.Context, options *ListSegmentSubscriberOptions) (*rootSubscribers, *Response, error) {
-	path := fmt.Sprintf("%s/%s/subscribers", segmentEndpoint, options.SegmentID)
+	path := buildPath(segmentEndpoint, options.SegmentID, "subscribers")
 
 	req, err := s.client.newRequest(http.MethodGet, path, options)
 	if err != nil {
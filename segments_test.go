@@ -0,0 +1,194 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListSegments(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/segments?limit=10&page=1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{
+						"id": "1",
+						"name": "Engaged",
+						"total": 120,
+						"open_rate": {"float": 0.4, "string": "40%"},
+						"click_rate": {"float": 0.1, "string": "10%"},
+						"created_at": "2022-01-01 10:00:00"
+					}
+				],
+				"meta": {"total": 1}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	segments, _, err := client.Segment.List(ctx, &mailerlite.ListSegmentOptions{ListOptions: mailerlite.ListOptions{Page: 1, Limit: 10}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Engaged", segments.Data[0].Name)
+	assert.Equal(t, 120, segments.Data[0].Total)
+	assert.Equal(t, "10%", segments.Data[0].ClickRate.String)
+}
+
+func TestCanListSegmentSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/segments/1/subscribers?filter%5Bstatus%5D=active&limit=10", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [{"id": "123456789", "email": "client@example.com", "status": "active"}]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSegmentSubscriberOptions{
+		SegmentID:   "1",
+		ListOptions: mailerlite.ListOptions{Limit: 10},
+		Filters:     &[]mailerlite.Filter{{Name: "status", Value: "active"}},
+	}
+
+	subscribers, _, err := client.Segment.Subscribers(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "client@example.com", subscribers.Data[0].Email)
+}
+
+func TestCanUpdateSegment(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/segments/1", req.URL.String())
+		assert.JSONEq(t, `{"name":"Most engaged"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Most engaged"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	segment, _, err := client.Segment.Update(ctx, "1", &mailerlite.UpdateSegmentOptions{Name: "Most engaged"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Most engaged", segment.Data.Name)
+}
+
+func TestCanDeleteSegment(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/segments/1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Segment.Delete(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestCanGetSingleSegment(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body := `{"data": [{"id": "1", "name": "First"}], "meta": {"current_page": 1, "last_page": 2}}`
+		if req.URL.Query().Get("page") == "2" {
+			body = `{
+				"data": [{
+					"id": "2",
+					"name": "Engaged",
+					"total": 340,
+					"open_rate": {"float": 0.52, "string": "52%"},
+					"click_rate": {"float": 0.12, "string": "12%"}
+				}],
+				"meta": {"current_page": 2, "last_page": 2}
+			}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	segment, _, err := client.Segment.Get(context.TODO(), "2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Engaged", segment.Data.Name)
+	assert.Equal(t, 340, segment.Data.Total)
+	assert.Equal(t, 0.52, segment.Data.OpenRate.Float)
+	assert.Equal(t, "12%", segment.Data.ClickRate.String)
+
+	_, _, err = client.Segment.Get(context.TODO(), "3")
+	assert.True(t, mailerlite.IsNotFound(err))
+	assert.Equal(t, http.StatusNotFound, mailerlite.StatusCode(err))
+	assert.Contains(t, err.Error(), "segment 3 not found")
+}
+
+func TestCanGetSingleSegmentFollowingLinks(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var pages []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+		body := `{"data": [{"id": "1"}], "links": {"next": "https://connect.mailerlite.com/api/segments?page=2"}}`
+		if page == "2" {
+			body = `{"data": [{"id": "2"}], "links": {"next": null}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Segment.Get(context.TODO(), "3")
+
+	assert.True(t, mailerlite.IsNotFound(err))
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
@@ -0,0 +1,84 @@
+package mailerlite
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultSimpleTimeout is the timeout of SimpleClient calls when the http client has none
+const DefaultSimpleTimeout = 30 * time.Second
+
+// SimpleClient - a wrapper for quick scripts, its methods do not take a context and use a background
+// context with the client timeout instead. Use Client for everything else, it remains the primary API.
+type SimpleClient struct {
+	Client *Client
+}
+
+// NewSimpleClient - creates a new simple client instance
+func NewSimpleClient(apiKey string, opts ...ClientOption) *SimpleClient {
+	return &SimpleClient{Client: NewClient(apiKey, opts...)}
+}
+
+func (c *SimpleClient) context() (context.Context, context.CancelFunc) {
+	timeout := c.Client.Client().Timeout
+	if timeout == 0 {
+		timeout = DefaultSimpleTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// ListSubscribers - see SubscriberService.List
+func (c *SimpleClient) ListSubscribers(options *ListSubscriberOptions) (*rootSubscribers, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Subscriber.List(ctx, options)
+}
+
+// GetSubscriber - see SubscriberService.Get
+func (c *SimpleClient) GetSubscriber(options *GetSubscriberOptions) (*rootSubscriber, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Subscriber.Get(ctx, options)
+}
+
+// CreateSubscriber - see SubscriberService.Create
+func (c *SimpleClient) CreateSubscriber(subscriber *CreateSubscriberOptions) (*rootSubscriber, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Subscriber.Create(ctx, subscriber)
+}
+
+// UpdateSubscriber - see SubscriberService.Update
+func (c *SimpleClient) UpdateSubscriber(subscriberID string, subscriber *UpdateSubscriberOptions) (*rootSubscriber, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Subscriber.Update(ctx, subscriberID, subscriber)
+}
+
+// DeleteSubscriber - see SubscriberService.Delete
+func (c *SimpleClient) DeleteSubscriber(subscriberID string) (*Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Subscriber.Delete(ctx, subscriberID)
+}
+
+// ListGroups - see GroupService.List
+func (c *SimpleClient) ListGroups(options *ListGroupOptions) (*rootGroups, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Group.List(ctx, options)
+}
+
+// CreateGroup - see GroupService.Create
+func (c *SimpleClient) CreateGroup(options *CreateGroupOptions) (*rootGroup, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Group.Create(ctx, options)
+}
+
+// ListCampaigns - see CampaignService.List
+func (c *SimpleClient) ListCampaigns(options *ListCampaignOptions) (*rootCampaigns, *Response, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.Client.Campaign.List(ctx, options)
+}
@@ -0,0 +1,66 @@
+package mailerlite_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleClientHitsTheSameEndpoints(t *testing.T) {
+	var requests []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		_, hasDeadline := req.Context().Deadline()
+		assert.True(t, hasDeadline)
+
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		body := `{"data": {"id": "1"}}`
+		if req.Method == http.MethodGet {
+			body = `{"data": [{"id": "1"}]}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client := mailerlite.NewSimpleClient(testKey, mailerlite.WithHTTPClient(testClient))
+
+	subscribers, _, err := client.ListSubscribers(nil)
+	assert.NoError(t, err)
+	assert.Len(t, subscribers.Data, 1)
+
+	subscriber, _, err := client.CreateSubscriber(&mailerlite.CreateSubscriberOptions{Email: "test@test.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", subscriber.Data.ID)
+
+	_, _, err = client.UpdateSubscriber("1", &mailerlite.UpdateSubscriberOptions{Status: "active"})
+	assert.NoError(t, err)
+
+	_, err = client.DeleteSubscriber("1")
+	assert.NoError(t, err)
+
+	_, _, err = client.ListGroups(nil)
+	assert.NoError(t, err)
+
+	_, _, err = client.CreateGroup(&mailerlite.CreateGroupOptions{Name: "News"})
+	assert.NoError(t, err)
+
+	_, _, err = client.ListCampaigns(nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /api/subscribers",
+		"POST /api/subscribers",
+		"PUT /api/subscribers/1",
+		"DELETE /api/subscribers/1",
+		"GET /api/groups",
+		"POST /api/groups",
+		"GET /api/campaigns",
+	}, requests)
+}
@@ -2,8 +2,15 @@ package mailerlite
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 const subscriberEndpoint = "/subscribers"
@@ -26,10 +33,59 @@ type count struct {
 	Total int `json:"total"`
 }
 
+// UnmarshalJSON reads the total either from the top level or from the meta of a list response
+func (c *count) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Total *int `json:"total"`
+		Meta  struct {
+			Total int `json:"total"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Total = raw.Meta.Total
+	if raw.Total != nil {
+		c.Total = *raw.Total
+	}
+
+	return nil
+}
+
+// SubscriberStatus - the status of a subscriber
+type SubscriberStatus string
+
+const (
+	SubscriberStatusActive       SubscriberStatus = "active"
+	SubscriberStatusUnsubscribed SubscriberStatus = "unsubscribed"
+	SubscriberStatusUnconfirmed  SubscriberStatus = "unconfirmed"
+	SubscriberStatusBounced      SubscriberStatus = "bounced"
+	SubscriberStatusJunk         SubscriberStatus = "junk"
+)
+
+// IsValid reports whether the status is one of the known subscriber statuses
+func (s SubscriberStatus) IsValid() bool {
+	switch s {
+	case SubscriberStatusActive, SubscriberStatusUnsubscribed, SubscriberStatusUnconfirmed, SubscriberStatusBounced, SubscriberStatusJunk:
+		return true
+	default:
+		return false
+	}
+}
+
+// StatusFilter - returns a filter on the subscriber status, unknown statuses are rejected before a request is made
+func StatusFilter(status SubscriberStatus) (*Filter, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid subscriber status %q", status)
+	}
+	return NewFilter("status", string(status)), nil
+}
+
 type Subscriber struct {
 	ID             string                 `json:"id,omitempty"`
 	Email          string                 `json:"email,omitempty"`
-	Status         string                 `json:"status,omitempty"`
+	Status         SubscriberStatus       `json:"status,omitempty"`
 	Source         string                 `json:"source,omitempty"`
 	Sent           int                    `json:"sent,omitempty"`
 	OpensCount     int                    `json:"opens_count,omitempty"`
@@ -37,16 +93,117 @@ type Subscriber struct {
 	OpenRate       float64                `json:"open_rate,omitempty"`
 	ClickRate      float64                `json:"click_rate,omitempty"`
 	IPAddress      interface{}            `json:"ip_address,omitempty"`
-	SubscribedAt   string                 `json:"subscribed_at,omitempty"`
-	UnsubscribedAt interface{}            `json:"unsubscribed_at,omitempty"`
-	CreatedAt      string                 `json:"created_at,omitempty"`
-	UpdatedAt      string                 `json:"updated_at,omitempty"`
+	SubscribedAt   Timestamp              `json:"subscribed_at"`
+	UnsubscribedAt *Timestamp             `json:"unsubscribed_at,omitempty"`
+	CreatedAt      Timestamp              `json:"created_at"`
+	UpdatedAt      Timestamp              `json:"updated_at"`
 	Fields         map[string]interface{} `json:"fields,omitempty"`
 	Groups         []Group                `json:"groups,omitempty"`
+	OptedInAt      *Timestamp             `json:"opted_in_at,omitempty"`
+	OptinIP        string                 `json:"optin_ip,omitempty"`
+	DeletedAt      *Timestamp             `json:"deleted_at,omitempty"`
+
+	// UnsubscribeReason is the reason or source given when the subscriber unsubscribed
+	UnsubscribeReason string `json:"unsubscribe_reason,omitempty"`
+}
+
+type rootActivities struct {
+	Data  []Activity `json:"data"`
+	Links Links      `json:"links"`
+	Meta  Meta       `json:"meta"`
+}
+
+// Activity - an event in the history of a subscriber, e.g. an opened campaign or a started automation
+type Activity struct {
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	Date       Timestamp          `json:"date"`
+	Campaign   *ActivityReference `json:"campaign,omitempty"`
+	Automation *ActivityReference `json:"automation,omitempty"`
+}
+
+// ActivityReference - the campaign or automation an activity relates to
+type ActivityReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FieldString returns a custom field value as a string, ok is false when it is missing or not a string
+func (s *Subscriber) FieldString(key string) (string, bool) {
+	value, ok := s.Fields[key].(string)
+	return value, ok
+}
+
+// FieldInt returns a custom field value as an int, ok is false when it is missing or not a whole number
+func (s *Subscriber) FieldInt(key string) (int, bool) {
+	switch value := s.Fields[key].(type) {
+	case float64:
+		if value != float64(int(value)) {
+			return 0, false
+		}
+		return int(value), true
+	case int:
+		return value, true
+	case json.Number:
+		number, err := value.Int64()
+		return int(number), err == nil
+	default:
+		return 0, false
+	}
+}
+
+// FieldTime returns a custom date field value as a time, ok is false when it is missing or not a date
+func (s *Subscriber) FieldTime(key string) (time.Time, bool) {
+	value, ok := s.Fields[key].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{TimestampLayout, "2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// CreateSubscriberOptions - modifies the behavior of SubscriberService.Create method
+type CreateSubscriberOptions struct {
+	Email          string                 `json:"email"`
+	Fields         map[string]interface{} `json:"fields,omitempty"`
+	Groups         []string               `json:"groups,omitempty"`
+	Status         SubscriberStatus       `json:"status,omitempty"`
+	SubscribedAt   string                 `json:"subscribed_at,omitempty"`
+	IPAddress      string                 `json:"ip_address,omitempty"`
 	OptedInAt      string                 `json:"opted_in_at,omitempty"`
 	OptinIP        string                 `json:"optin_ip,omitempty"`
+	UnsubscribedAt string                 `json:"unsubscribed_at,omitempty"`
+
+	// DryRun only validates the options, the API has no validation mode so nothing is sent
+	DryRun bool `json:"-"`
 }
 
+// Validate - check the options the API requires before creating a subscriber
+func (o *CreateSubscriberOptions) Validate() error {
+	if o.Email == "" {
+		return errors.New("subscriber email is required")
+	}
+	if !strings.Contains(o.Email, "@") {
+		return fmt.Errorf("invalid subscriber email %q", o.Email)
+	}
+	if o.Status != "" && !o.Status.IsValid() {
+		return fmt.Errorf("invalid subscriber status %q", o.Status)
+	}
+	return nil
+}
+
+// UpsertSubscriberOptions - modifies the behavior of SubscriberService.Upsert method
+type UpsertSubscriberOptions = CreateSubscriberOptions
+
+// NewSubscriber - the former options of SubscriberService.Create
+//
+// Deprecated: use CreateSubscriberOptions, or convert with NewSubscriber.CreateOptions.
 type NewSubscriber struct {
 	Email          string   `json:"email"`
 	Fields         Fields   `json:"fields"`
@@ -59,16 +216,92 @@ type NewSubscriber struct {
 	UnsubscribedAt string   `json:"unsubscribed_at"`
 }
 
+// Fields - the former custom fields of NewSubscriber
+//
+// Deprecated: CreateSubscriberOptions.Fields is a map keyed by field key, e.g. {"name": "John", "last_name": "Doe"}.
 type Fields struct {
 	Name     string `json:"name"`
 	LastName string `json:"last_name"`
 }
 
+// CreateOptions - convert the deprecated NewSubscriber to CreateSubscriberOptions
+func (n *NewSubscriber) CreateOptions() *CreateSubscriberOptions {
+	options := &CreateSubscriberOptions{
+		Email:          n.Email,
+		Groups:         n.GroupIds,
+		Status:         SubscriberStatus(n.Status),
+		SubscribedAt:   n.SubscribedAt,
+		IPAddress:      n.IPAddress,
+		OptedInAt:      n.OptedInAt,
+		OptinIP:        n.OptinIP,
+		UnsubscribedAt: n.UnsubscribedAt,
+	}
+	if n.Fields.Name != "" || n.Fields.LastName != "" {
+		options.Fields = map[string]interface{}{}
+		if n.Fields.Name != "" {
+			options.Fields["name"] = n.Fields.Name
+		}
+		if n.Fields.LastName != "" {
+			options.Fields["last_name"] = n.Fields.LastName
+		}
+	}
+	return options
+}
+
+// UpdateSubscriberOptions - modifies the behavior of SubscriberService.Update method
+type UpdateSubscriberOptions struct {
+	Fields         map[string]interface{} `json:"fields,omitempty"`
+	Groups         []string               `json:"groups,omitempty"`
+	Status         SubscriberStatus       `json:"status,omitempty"`
+	SubscribedAt   string                 `json:"subscribed_at,omitempty"`
+	IPAddress      string                 `json:"ip_address,omitempty"`
+	OptedInAt      string                 `json:"opted_in_at,omitempty"`
+	OptinIP        string                 `json:"optin_ip,omitempty"`
+	UnsubscribedAt string                 `json:"unsubscribed_at,omitempty"`
+}
+
+// ImportSubscribersOptions - modifies the behavior of SubscriberService.Import method
+type ImportSubscribersOptions struct {
+	GroupID     string                    `json:"-"`
+	Subscribers []CreateSubscriberOptions `json:"subscribers"`
+
+	// DryRun only validates the options, the API has no validation mode so nothing is sent
+	DryRun bool `json:"-"`
+}
+
+// Validate - check the group and every subscriber of the import
+func (o *ImportSubscribersOptions) Validate() error {
+	if o.GroupID == "" {
+		return errors.New("import group id is required")
+	}
+	if len(o.Subscribers) == 0 {
+		return errors.New("import contains no subscribers")
+	}
+	for i := range o.Subscribers {
+		if err := o.Subscribers[i].Validate(); err != nil {
+			return fmt.Errorf("subscriber %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ImportResult - the import job created by SubscriberService.Import
+type ImportResult struct {
+	ID                string `json:"-"`
+	ImportProgressURL string `json:"import_progress_url"`
+}
+
 // ListSubscriberOptions - modifies the behavior of SubscriberService.List method
 type ListSubscriberOptions struct {
+	Filters *[]Filter    `json:"filters,omitempty"`
+	Fields  FieldFilters `url:"fields,omitempty"`
+	ListOptions
+}
+
+// ListActivityOptions - modifies the behavior of SubscriberService.Activity method
+type ListActivityOptions struct {
 	Filters *[]Filter `json:"filters,omitempty"`
-	Page    int       `url:"page,omitempty"`
-	Limit   int       `url:"limit,omitempty"`
+	ListOptions
 }
 
 // GetSubscriberOptions - modifies the behavior of SubscriberService.Get method
@@ -92,10 +325,178 @@ func (s *SubscriberService) List(ctx context.Context, options *ListSubscriberOpt
 	return root, res, nil
 }
 
+// ListByGroup - list subscribers of a group with the given status, active when the status is empty
+func (s *SubscriberService) ListByGroup(ctx context.Context, groupID string, status SubscriberStatus, options *ListSubscriberOptions) (*rootSubscribers, *Response, error) {
+	if status == "" {
+		status = SubscriberStatusActive
+	}
+
+	statusFilter, err := StatusFilter(status)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listOptions := ListSubscriberOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+
+	var filters []Filter
+	if listOptions.Filters != nil {
+		filters = append(filters, *listOptions.Filters...)
+	}
+	filters = append(filters, *statusFilter)
+	listOptions.Filters = &filters
+
+	path := buildPath(groupEndpoint, groupID, "subscribers")
+
+	req, err := s.client.newRequest(http.MethodGet, path, &listOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootSubscribers)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+// ListAll - iterate over all subscribers, pages are fetched lazily while iterating
+func (s *SubscriberService) ListAll(ctx context.Context, options *ListSubscriberOptions) *SubscriberIterator {
+	listOptions := ListSubscriberOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+
+	return &SubscriberIterator{pages: pageIterator[Subscriber]{
+		ctx:  ctx,
+		next: PageRequest{Page: listOptions.Page, Cursor: listOptions.Cursor},
+		fetch: func(ctx context.Context, page PageRequest) ([]Subscriber, Paginated, error) {
+			listOptions.Page, listOptions.Cursor = page.Page, page.Cursor
+			root, _, err := s.List(ctx, &listOptions)
+			if err != nil {
+				return nil, nil, err
+			}
+			return root.Data, root, nil
+		},
+	}}
+}
+
+// SubscriberIterator iterates over subscribers returned by SubscriberService.ListAll
+type SubscriberIterator struct {
+	pages pageIterator[Subscriber]
+}
+
+// Next advances to the next subscriber, it returns false when there are no more subscribers or an error occurred
+func (it *SubscriberIterator) Next() bool {
+	return it.pages.advance()
+}
+
+// Subscriber returns the current subscriber
+func (it *SubscriberIterator) Subscriber() *Subscriber {
+	return it.pages.current
+}
+
+// Err returns the error that stopped the iteration
+func (it *SubscriberIterator) Err() error {
+	return it.pages.err
+}
+
+// CollectField - collect the value of a custom field for all subscribers matching the options, keyed by subscriber ID
+func (s *SubscriberService) CollectField(ctx context.Context, fieldKey string, options *ListSubscriberOptions) (map[string]interface{}, *Response, error) {
+	values := make(map[string]interface{})
+
+	listOptions := ListSubscriberOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		root, res, err := s.List(ctx, &listOptions)
+		if err != nil {
+			return nil, res, err
+		}
+
+		for _, subscriber := range root.Data {
+			values[subscriber.ID] = subscriber.Fields[fieldKey]
+		}
+
+		cursor, err := root.Links.NextPageToken()
+		if err != nil {
+			return nil, res, err
+		}
+		if root.Links.IsLastPage() || cursor == "" {
+			return values, res, nil
+		}
+		listOptions.Cursor = cursor
+	}
+}
+
+// ExportNDJSON - write all subscribers matching the options to w as newline delimited JSON and return how many
+// were written. Writers with a Flush method, e.g. bufio.Writer, are flushed after every line.
+func (s *SubscriberService) ExportNDJSON(ctx context.Context, w io.Writer, options *ListSubscriberOptions) (int, error) {
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() error })
+
+	written := 0
+	subscribers := s.ListAll(ctx, options)
+	for subscribers.Next() {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		if err := encoder.Encode(subscribers.Subscriber()); err != nil {
+			return written, err
+		}
+		if flusher != nil {
+			if err := flusher.Flush(); err != nil {
+				return written, err
+			}
+		}
+		written++
+	}
+
+	return written, subscribers.Err()
+}
+
+// ExportCSV - request the subscribers matching the options as text/csv, the body is returned undecoded
+// and the caller must close it. A response of another content type is closed and returned as an error.
+func (s *SubscriberService) ExportCSV(ctx context.Context, options *ListSubscriberOptions) (io.ReadCloser, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, subscriberEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := s.client.DoStream(WithHeader(ctx, "Accept", "text/csv"), req)
+	if err != nil {
+		return nil, res, err
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/csv" {
+		drainAndClose(res.Body)
+		return nil, res, fmt.Errorf("expected a text/csv response, got %q", contentType)
+	}
+
+	return res.Body, res, nil
+}
+
+// countSubscriberOptions - asks for an empty page, ListOptions would drop the zero limit and reject it
+type countSubscriberOptions struct {
+	Limit int `url:"limit"`
+}
+
 // Count - get a count of subscribers
 func (s *SubscriberService) Count(ctx context.Context) (*count, *Response, error) {
-	path := fmt.Sprintf("%s?limit=0", subscriberEndpoint)
-	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	path := buildPath(subscriberEndpoint)
+	req, err := s.client.newRequest(http.MethodGet, path, &countSubscriberOptions{Limit: 0})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,13 +510,31 @@ func (s *SubscriberService) Count(ctx context.Context) (*count, *Response, error
 	return root, res, nil
 }
 
+// CountByStatus - get the number of subscribers per status, the counts come from the account stats in a single request
+func (s *SubscriberService) CountByStatus(ctx context.Context) (map[string]int, *Response, error) {
+	root, res, err := s.client.Account.Stats(ctx)
+	if err != nil {
+		return nil, res, err
+	}
+
+	counts := map[string]int{
+		string(SubscriberStatusActive):       root.Data.Subscribed,
+		string(SubscriberStatusUnsubscribed): root.Data.Unsubscribed,
+		string(SubscriberStatusUnconfirmed):  root.Data.Unconfirmed,
+		string(SubscriberStatusBounced):      root.Data.Bounced,
+		string(SubscriberStatusJunk):         root.Data.JunkTotal,
+	}
+
+	return counts, res, nil
+}
+
 // Get - get a single subscriber by email or ID
 func (s *SubscriberService) Get(ctx context.Context, options *GetSubscriberOptions) (*rootSubscriber, *Response, error) {
 	param := options.SubscriberID
 	if options.Email != "" {
 		param = options.Email
 	}
-	path := fmt.Sprintf("%s/%s", subscriberEndpoint, param)
+	path := buildPath(subscriberEndpoint, param)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -130,7 +549,17 @@ func (s *SubscriberService) Get(ctx context.Context, options *GetSubscriberOptio
 	return root, res, nil
 }
 
-func (s *SubscriberService) Create(ctx context.Context, subscriber *NewSubscriber) (*rootSubscriber, *Response, error) {
+// GetByEmail - get a single subscriber by email, returns a NotFoundError if there is no such subscriber
+func (s *SubscriberService) GetByEmail(ctx context.Context, email string) (*rootSubscriber, *Response, error) {
+	return s.Get(ctx, &GetSubscriberOptions{Email: email})
+}
+
+// Create - create a new subscriber, with DryRun the options are only validated and nil is returned on success
+func (s *SubscriberService) Create(ctx context.Context, subscriber *CreateSubscriberOptions) (*rootSubscriber, *Response, error) {
+	if subscriber.DryRun {
+		return nil, nil, subscriber.Validate()
+	}
+
 	req, err := s.client.newRequest(http.MethodPost, subscriberEndpoint, subscriber)
 	if err != nil {
 		return nil, nil, err
@@ -145,12 +574,15 @@ func (s *SubscriberService) Create(ctx context.Context, subscriber *NewSubscribe
 	return root, res, nil
 }
 
-func (s *SubscriberService) Upsert(ctx context.Context, subscriber *NewSubscriber) (*rootSubscriber, *Response, error) {
+// Upsert - create a subscriber or update the existing one with the same email, the response
+// status is 201 Created when the subscriber is new and 200 OK when it was updated
+func (s *SubscriberService) Upsert(ctx context.Context, subscriber *UpsertSubscriberOptions) (*rootSubscriber, *Response, error) {
 	return s.Create(ctx, subscriber)
 }
 
-func (s *SubscriberService) Update(ctx context.Context, subscriber *Subscriber) (*rootSubscriber, *Response, error) {
-	path := fmt.Sprintf("%s/%s", subscriberEndpoint, subscriber.ID)
+// Update - update an existing subscriber, only the provided values are changed
+func (s *SubscriberService) Update(ctx context.Context, subscriberID string, subscriber *UpdateSubscriberOptions) (*rootSubscriber, *Response, error) {
+	path := buildPath(subscriberEndpoint, subscriberID)
 
 	req, err := s.client.newRequest(http.MethodPut, path, subscriber)
 	if err != nil {
@@ -166,8 +598,33 @@ func (s *SubscriberService) Update(ctx context.Context, subscriber *Subscriber)
 	return root, res, nil
 }
 
+// UpdateFields - update only custom fields of a subscriber, fields that are not provided keep their values
+func (s *SubscriberService) UpdateFields(ctx context.Context, subscriberID string, fields map[string]interface{}) (*Subscriber, *Response, error) {
+	root, res, err := s.Update(ctx, subscriberID, &UpdateSubscriberOptions{Fields: fields})
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &root.Data, res, nil
+}
+
+// SetStatus - change only the status of a subscriber, e.g. to restore one wrongly marked as junk
+func (s *SubscriberService) SetStatus(ctx context.Context, subscriberID string, status SubscriberStatus) (*Subscriber, *Response, error) {
+	if !status.IsValid() {
+		return nil, nil, fmt.Errorf("invalid subscriber status %q", status)
+	}
+
+	root, res, err := s.Update(ctx, subscriberID, &UpdateSubscriberOptions{Status: status})
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &root.Data, res, nil
+}
+
+// Delete - delete a subscriber, the API responds with 204 No Content
 func (s *SubscriberService) Delete(ctx context.Context, subscriberID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", subscriberEndpoint, subscriberID)
+	path := buildPath(subscriberEndpoint, subscriberID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
@@ -182,8 +639,9 @@ func (s *SubscriberService) Delete(ctx context.Context, subscriberID string) (*R
 	return res, nil
 }
 
+// Forget - forget a subscriber, the subscriber data is scheduled for complete deletion
 func (s *SubscriberService) Forget(ctx context.Context, subscriberID string) (*rootSubscriber, *Response, error) {
-	path := fmt.Sprintf("%s/%s/forget", subscriberEndpoint, subscriberID)
+	path := buildPath(subscriberEndpoint, subscriberID, "forget")
 
 	req, err := s.client.newRequest(http.MethodPost, path, nil)
 	if err != nil {
@@ -198,3 +656,142 @@ func (s *SubscriberService) Forget(ctx context.Context, subscriberID string) (*r
 
 	return root, res, nil
 }
+
+// ForgetManyResult - the outcome of SubscriberService.ForgetMany by email
+type ForgetManyResult struct {
+	Forgotten []string
+	NotFound  []string
+	// Errors holds the reason a subscriber could not be forgotten by email
+	Errors map[string]error
+}
+
+// ForgetMany - forget the subscribers with the given emails, e.g. for a bulk data deletion request.
+// Missing subscribers and failures are reported in the result without stopping the others.
+func (s *SubscriberService) ForgetMany(ctx context.Context, emails []string) (*ForgetManyResult, *Response, error) {
+	result := &ForgetManyResult{Errors: make(map[string]error)}
+
+	var res *Response
+	for _, email := range emails {
+		if err := ctx.Err(); err != nil {
+			return result, res, err
+		}
+
+		var subscriber *rootSubscriber
+		var err error
+		subscriber, res, err = s.GetByEmail(ctx, email)
+		if IsNotFound(err) {
+			result.NotFound = append(result.NotFound, email)
+			continue
+		}
+		if err != nil {
+			result.Errors[email] = err
+			continue
+		}
+
+		_, res, err = s.Forget(ctx, subscriber.Data.ID)
+		if err != nil {
+			result.Errors[email] = err
+			continue
+		}
+
+		result.Forgotten = append(result.Forgotten, email)
+	}
+
+	return result, res, nil
+}
+
+// ListGroups - list groups a subscriber belongs to
+func (s *SubscriberService) ListGroups(ctx context.Context, subscriberID string, options *ListGroupOptions) (*rootGroups, *Response, error) {
+	path := buildPath(subscriberEndpoint, subscriberID, "groups")
+
+	req, err := s.client.newRequest(http.MethodGet, path, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootGroups)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+// Activity - list the activity of a subscriber, including the automations it went through
+func (s *SubscriberService) Activity(ctx context.Context, subscriberID string, options *ListActivityOptions) (*rootActivities, *Response, error) {
+	path := buildPath(subscriberEndpoint, subscriberID, "activity")
+
+	req, err := s.client.newRequest(http.MethodGet, path, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootActivities)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+// AssignToGroup - assign a subscriber to a group
+func (s *SubscriberService) AssignToGroup(ctx context.Context, subscriberID, groupID string) (*Response, error) {
+	path := buildPath(subscriberEndpoint, subscriberID, "groups", groupID)
+
+	req, err := s.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// UnassignFromGroup - remove a subscriber from a group
+func (s *SubscriberService) UnassignFromGroup(ctx context.Context, subscriberID, groupID string) (*Response, error) {
+	path := buildPath(subscriberEndpoint, subscriberID, "groups", groupID)
+
+	req, err := s.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Import - import subscribers into a group, the import runs asynchronously on the MailerLite side, DryRun only validates the options
+func (s *SubscriberService) Import(ctx context.Context, options *ImportSubscribersOptions) (*ImportResult, *Response, error) {
+	if options.DryRun {
+		return nil, nil, options.Validate()
+	}
+
+	path := buildPath(groupEndpoint, options.GroupID, "import-subscribers")
+
+	req, err := s.client.newRequest(http.MethodPost, path, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ImportResult)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	if progressURL, err := url.Parse(root.ImportProgressURL); err == nil {
+		root.ID = progressURL.Path[strings.LastIndex(progressURL.Path, "/")+1:]
+	}
+
+	return root, res, nil
+}
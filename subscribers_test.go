@@ -3,9 +3,12 @@ package mailerlite_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mailerlite/mailerlite-go"
 	"github.com/stretchr/testify/assert"
@@ -37,6 +40,29 @@ func TestCanListSubscrbers(t *testing.T) {
 
 }
 
+func TestCanCheckForEmptySubscriberList(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": [], "links": {"next": null}, "meta": {"per_page": 25}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSubscriberOptions{
+		Filters: &[]mailerlite.Filter{{Name: "status", Value: "junk"}},
+	}
+
+	subscribers, _, err := client.Subscriber.List(context.TODO(), options)
+
+	assert.NoError(t, err)
+	assert.True(t, subscribers.IsEmpty())
+}
+
 func TestCanGetSingleSubscrber(t *testing.T) {
 	client := mailerlite.NewClient(testKey)
 
@@ -91,6 +117,24 @@ func TestCanGetSingleSubscrberByEmail(t *testing.T) {
 	assert.Equal(t, res.StatusCode, http.StatusAccepted)
 }
 
+func TestCanDecodeUnsubscribedSubscriber(t *testing.T) {
+	var subscriber mailerlite.Subscriber
+	err := json.Unmarshal([]byte(`{
+		"id": "1",
+		"email": "client@example.com",
+		"status": "unsubscribed",
+		"optin_ip": "127.0.0.1",
+		"unsubscribed_at": "2023-05-01 10:00:00",
+		"unsubscribe_reason": "no_longer_interested"
+	}`), &subscriber)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mailerlite.SubscriberStatusUnsubscribed, subscriber.Status)
+	assert.Equal(t, "127.0.0.1", subscriber.OptinIP)
+	assert.Equal(t, "2023-05-01 10:00:00", subscriber.UnsubscribedAt.Format(mailerlite.TimestampLayout))
+	assert.Equal(t, "no_longer_interested", subscriber.UnsubscribeReason)
+}
+
 func TestCanCreateSubscrber(t *testing.T) {
 	client := mailerlite.NewClient(testKey)
 
@@ -107,7 +151,7 @@ func TestCanCreateSubscrber(t *testing.T) {
 
 	client.SetHttpClient(testClient)
 
-	options := &mailerlite.NewSubscriber{
+	options := &mailerlite.CreateSubscriberOptions{
 		Email: "test@test.com",
 	}
 
@@ -166,3 +210,1047 @@ func TestCanForgetSubscrber(t *testing.T) {
 
 	assert.Equal(t, res.StatusCode, http.StatusOK)
 }
+
+func TestCanGetSingleSubscriberByID(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/123456789", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "123456789",
+					"email": "client@example.com",
+					"status": "active"
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.GetSubscriberOptions{
+		SubscriberID: "123456789",
+	}
+
+	subscriber, res, err := client.Subscriber.Get(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "123456789", subscriber.Data.ID)
+	assert.Equal(t, "client@example.com", subscriber.Data.Email)
+}
+
+func TestWillHandleSubscriberNotFound(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Resource not found."}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.GetSubscriberOptions{
+		SubscriberID: "123456789",
+	}
+
+	subscriber, res, err := client.Subscriber.Get(ctx, options)
+
+	assert.Nil(t, subscriber)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Error(t, err)
+	assert.IsType(t, &mailerlite.NotFoundError{}, err)
+	assert.Equal(t, "GET https://connect.mailerlite.com/api/subscribers/123456789: 404 Resource not found. map[]", err.Error())
+}
+
+func TestCanGetSingleSubscriberByEscapedEmail(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/first%2Flast+tag@example.com", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "123456789",
+					"email": "first/last+tag@example.com",
+					"status": "active"
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	subscriber, _, err := client.Subscriber.GetByEmail(ctx, "first/last+tag@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789", subscriber.Data.ID)
+	assert.Equal(t, "first/last+tag@example.com", subscriber.Data.Email)
+}
+
+func TestCanCreateSubscriberWithPartialBody(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers", req.URL.String())
+		assert.JSONEq(t, `{"email":"test@test.com","fields":{"city":"Vilnius"},"groups":["1234"]}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "123456789",
+					"email": "test@test.com",
+					"status": "active",
+					"fields": {"city": "Vilnius"}
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.CreateSubscriberOptions{
+		Email:  "test@test.com",
+		Fields: map[string]interface{}{"city": "Vilnius"},
+		Groups: []string{"1234"},
+	}
+
+	subscriber, res, err := client.Subscriber.Create(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, "123456789", subscriber.Data.ID)
+	assert.Equal(t, "Vilnius", subscriber.Data.Fields["city"])
+}
+
+func TestWillHandleSubscriberValidationError(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusUnprocessableEntity,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{"message": "The given data was invalid.",
+			"errors": {"email": ["The email must be a valid email address."]}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	subscriber, _, err := client.Subscriber.Create(ctx, &mailerlite.CreateSubscriberOptions{Email: "invalid"})
+
+	assert.Nil(t, subscriber)
+	assert.IsType(t, &mailerlite.ErrorResponse{}, err)
+	if err, ok := err.(*mailerlite.ErrorResponse); ok {
+		assert.Equal(t, []string{"The email must be a valid email address."}, err.Errors["email"])
+	}
+}
+
+func TestCanUpdateSubscriberFieldsOnly(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/123456789", req.URL.String())
+		assert.JSONEq(t, `{"fields":{"company":"MailerLite"}}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "123456789",
+					"email": "test@test.com",
+					"fields": {"company": "MailerLite"}
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.UpdateSubscriberOptions{
+		Fields: map[string]interface{}{"company": "MailerLite"},
+	}
+
+	subscriber, _, err := client.Subscriber.Update(ctx, "123456789", options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "MailerLite", subscriber.Data.Fields["company"])
+}
+
+func TestCanDeleteSubscriberWithNoContent(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Subscriber.Delete(ctx, "1234")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestCanForgetSubscriberWithDeletionDate(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234/forget", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "1234",
+					"email": "test@test.com",
+					"deleted_at": "2023-05-01 10:00:00"
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	subscriber, _, err := client.Subscriber.Forget(ctx, "1234")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", subscriber.Data.ID)
+	assert.Equal(t, "2023-05-01 10:00:00", subscriber.Data.DeletedAt.Format(mailerlite.TimestampLayout))
+}
+
+func TestCanCountSubscribers(t *testing.T) {
+	bodies := []string{
+		`{"total": 42}`,
+		`{"data": [], "meta": {"total": 42}}`,
+	}
+
+	for _, body := range bodies {
+		client := mailerlite.NewClient(testKey)
+
+		testClient := NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "https://connect.mailerlite.com/api/subscribers?limit=0", req.URL.String())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}
+		})
+
+		client.SetHttpClient(testClient)
+
+		count, _, err := client.Subscriber.Count(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 42, count.Total)
+	}
+}
+
+func TestCanAssignSubscriberToGroup(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234/groups/5678", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "5678", "name": "Newsletter"}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Subscriber.AssignToGroup(ctx, "1234", "5678")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestCanUnassignSubscriberFromGroup(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234/groups/5678", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Subscriber.UnassignFromGroup(ctx, "1234", "5678")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestCanIterateOverAllSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	pages := map[string]string{
+		"https://connect.mailerlite.com/api/subscribers?limit=2": `{
+			"data": [{"id": "1"}, {"id": "2"}],
+			"links": {"next": "https://connect.mailerlite.com/api/subscribers?cursor=abc"}
+		}`,
+		"https://connect.mailerlite.com/api/subscribers?cursor=abc&limit=2": `{
+			"data": [{"id": "3"}],
+			"links": {"next": null}
+		}`,
+	}
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, ok := pages[req.URL.String()]
+		assert.True(t, ok, req.URL.String())
+		delete(pages, req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	iterator := client.Subscriber.ListAll(context.TODO(), &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 2}})
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Subscriber().ID)
+	}
+
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Empty(t, pages)
+}
+
+func TestWillStopIteratingWhenContextIsCancelled(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iterator := client.Subscriber.ListAll(ctx, nil)
+
+	assert.False(t, iterator.Next())
+	assert.ErrorIs(t, iterator.Err(), context.Canceled)
+}
+
+func TestWillOnlyValidateDryRunSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		t.Fatalf("dry run sent %s %s", req.Method, req.URL)
+		return nil
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	subscriber, res, err := client.Subscriber.Create(ctx, &mailerlite.CreateSubscriberOptions{Email: "client@example.com", DryRun: true})
+	assert.NoError(t, err)
+	assert.Nil(t, subscriber)
+	assert.Nil(t, res)
+
+	_, _, err = client.Subscriber.Create(ctx, &mailerlite.CreateSubscriberOptions{Email: "client", DryRun: true})
+	assert.EqualError(t, err, `invalid subscriber email "client"`)
+
+	_, _, err = client.Subscriber.Import(ctx, &mailerlite.ImportSubscribersOptions{
+		GroupID: "1",
+		Subscribers: []mailerlite.CreateSubscriberOptions{
+			{Email: "client@example.com"},
+			{Email: "other@example.com", Status: "deleted"},
+		},
+		DryRun: true,
+	})
+	assert.EqualError(t, err, `subscriber 1: invalid subscriber status "deleted"`)
+
+	_, _, err = client.Subscriber.Import(ctx, &mailerlite.ImportSubscribersOptions{GroupID: "1", DryRun: true})
+	assert.Error(t, err)
+
+	body, err := json.Marshal(&mailerlite.CreateSubscriberOptions{Email: "client@example.com", DryRun: true})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "dry")
+}
+
+func TestCanImportSubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/groups/1/import-subscribers", req.URL.String())
+		assert.JSONEq(t, `{"subscribers": [
+			{"email": "first@example.com", "fields": {"name": "First"}},
+			{"email": "second@example.com", "status": "unsubscribed"}
+		]}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"import_progress_url": "https://connect.mailerlite.com/api/subscribers/import/7"}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ImportSubscribersOptions{
+		GroupID: "1",
+		Subscribers: []mailerlite.CreateSubscriberOptions{
+			{Email: "first@example.com", Fields: map[string]interface{}{"name": "First"}},
+			{Email: "second@example.com", Status: "unsubscribed"},
+		},
+	}
+
+	result, _, err := client.Subscriber.Import(context.TODO(), options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "7", result.ID)
+	assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/import/7", result.ImportProgressURL)
+}
+
+func TestCanListSubscriberGroups(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234/groups?sort=name", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": [{"id": "1", "name": "Newsletter"}]}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	groups, _, err := client.Subscriber.ListGroups(context.TODO(), "1234", &mailerlite.ListGroupOptions{Sort: mailerlite.SortByName})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Newsletter", groups.Data[0].Name)
+}
+
+func TestCanRequestNextPageWithCursor(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body := `{"data": [{"id": "1"}], "links": {"next": "https://connect.mailerlite.com/api/subscribers?cursor=eyJpZCI6MX0"}}`
+		if req.URL.Query().Get("cursor") != "" {
+			assert.Equal(t, "https://connect.mailerlite.com/api/subscribers?cursor=eyJpZCI6MX0&limit=1", req.URL.String())
+			body = `{"data": [{"id": "2"}], "links": {"prev": "https://connect.mailerlite.com/api/subscribers?cursor=eyJpZCI6Mn0"}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 1}}
+
+	first, _, err := client.Subscriber.List(context.TODO(), options)
+	assert.NoError(t, err)
+
+	options.Cursor, err = first.Links.NextPageToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "eyJpZCI6MX0", options.Cursor)
+
+	second, _, err := client.Subscriber.List(context.TODO(), options)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", second.Data[0].ID)
+	assert.True(t, second.Links.IsLastPage())
+}
+
+func TestCanUpsertSubscriber(t *testing.T) {
+	for _, statusCode := range []int{http.StatusCreated, http.StatusOK} {
+		client := mailerlite.NewClient(testKey)
+
+		testClient := NewTestClient(func(req *http.Request) *http.Response {
+			body, _ := io.ReadAll(req.Body)
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "https://connect.mailerlite.com/api/subscribers", req.URL.String())
+			assert.JSONEq(t, `{"email":"test@test.com","status":"active"}`, string(body))
+			return &http.Response{
+				StatusCode: statusCode,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "123456789", "email": "test@test.com"}}`)),
+			}
+		})
+
+		client.SetHttpClient(testClient)
+
+		options := &mailerlite.UpsertSubscriberOptions{
+			Email:  "test@test.com",
+			Status: "active",
+		}
+
+		subscriber, res, err := client.Subscriber.Upsert(context.TODO(), options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123456789", subscriber.Data.ID)
+		assert.Equal(t, statusCode == http.StatusCreated, res.StatusCode == http.StatusCreated)
+	}
+}
+
+func TestCanReadTypedSubscriberFields(t *testing.T) {
+	subscriber := &mailerlite.Subscriber{
+		Fields: map[string]interface{}{
+			"city":     "Vilnius",
+			"age":      float64(31),
+			"score":    2.5,
+			"birthday": "1990-05-01",
+			"company":  nil,
+		},
+	}
+
+	city, ok := subscriber.FieldString("city")
+	assert.True(t, ok)
+	assert.Equal(t, "Vilnius", city)
+
+	_, ok = subscriber.FieldString("age")
+	assert.False(t, ok)
+
+	_, ok = subscriber.FieldString("missing")
+	assert.False(t, ok)
+
+	age, ok := subscriber.FieldInt("age")
+	assert.True(t, ok)
+	assert.Equal(t, 31, age)
+
+	_, ok = subscriber.FieldInt("score")
+	assert.False(t, ok)
+
+	_, ok = subscriber.FieldInt("city")
+	assert.False(t, ok)
+
+	birthday, ok := subscriber.FieldTime("birthday")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC), birthday)
+
+	_, ok = subscriber.FieldTime("city")
+	assert.False(t, ok)
+
+	_, ok = subscriber.FieldTime("company")
+	assert.False(t, ok)
+}
+
+func TestCanSendIdempotencyKey(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "create-test-1", req.Header.Get(mailerlite.HeaderIdempotencyKey))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "123456789"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	ctx := mailerlite.WithIdempotencyKey(context.TODO(), "create-test-1")
+
+	_, _, err := client.Subscriber.Create(ctx, &mailerlite.CreateSubscriberOptions{Email: "test@test.com"})
+
+	assert.NoError(t, err)
+}
+
+func TestCanListSubscribersByFieldValues(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		query := req.URL.Query()
+		assert.Equal(t, "active", query.Get("filter[status]"))
+		assert.Equal(t, "Acme", query.Get("filter[company]"))
+		assert.Equal(t, "Vilnius", query.Get("filter[city]"))
+		assert.Equal(t, "10", query.Get("limit"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": [{"id": "1", "email": "test@acme.com"}]}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSubscriberOptions{
+		Filters:     &[]mailerlite.Filter{{Name: "status", Value: "active"}},
+		Fields:      map[string]string{"company": "Acme", "city": "Vilnius"},
+		ListOptions: mailerlite.ListOptions{Limit: 10},
+	}
+
+	subscribers, _, err := client.Subscriber.List(context.TODO(), options)
+
+	assert.NoError(t, err)
+	assert.Len(t, subscribers.Data, 1)
+}
+
+func TestCanListSubscriberActivity(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234/activity?limit=2", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{"id": "1", "type": "automation_started", "date": "2022-05-10 08:00:00", "automation": {"id": "55", "name": "Welcome"}},
+					{"id": "2", "type": "campaign_opened", "date": "2022-05-11 09:30:00", "campaign": {"id": "77", "name": "Newsletter"}}
+				],
+				"links": {"next": null},
+				"meta": {"per_page": 2}
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	activity, _, err := client.Subscriber.Activity(context.TODO(), "1234", &mailerlite.ListActivityOptions{ListOptions: mailerlite.ListOptions{Limit: 2}})
+
+	assert.NoError(t, err)
+	assert.Len(t, activity.Data, 2)
+	assert.Equal(t, "automation_started", activity.Data[0].Type)
+	assert.Equal(t, "Welcome", activity.Data[0].Automation.Name)
+	assert.Nil(t, activity.Data[0].Campaign)
+	assert.Equal(t, "77", activity.Data[1].Campaign.ID)
+	assert.Equal(t, time.Date(2022, 5, 11, 9, 30, 0, 0, time.UTC), activity.Data[1].Date.Time)
+}
+
+func TestCanUpdateSubscriberFields(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234", req.URL.String())
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"fields": {"company": "Acme"}}`, string(body))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1234", "fields": {"company": "Acme", "city": "Vilnius"}}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	subscriber, _, err := client.Subscriber.UpdateFields(context.TODO(), "1234", map[string]interface{}{"company": "Acme"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", subscriber.ID)
+	city, _ := subscriber.FieldString("city")
+	assert.Equal(t, "Vilnius", city)
+}
+
+func TestCanSetSubscriberStatus(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	calls := 0
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers/1234", req.URL.String())
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"status": "active"}`, string(body))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1234", "status": "active"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	subscriber, _, err := client.Subscriber.SetStatus(context.TODO(), "1234", mailerlite.SubscriberStatusActive)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mailerlite.SubscriberStatusActive, subscriber.Status)
+
+	_, _, err = client.Subscriber.SetStatus(context.TODO(), "1234", "deleted")
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCanCollectFieldAcrossPages(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body := `{
+			"data": [
+				{"id": "1", "fields": {"company": "Acme"}},
+				{"id": "2", "fields": {"company": null}}
+			],
+			"links": {"next": "https://connect.mailerlite.com/api/subscribers?cursor=next"}
+		}`
+		if req.URL.Query().Get("cursor") == "next" {
+			body = `{"data": [{"id": "3", "fields": {"company": "Globex"}}], "links": {"next": null}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	values, _, err := client.Subscriber.CollectField(context.TODO(), "company", &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 2}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"1": "Acme", "2": nil, "3": "Globex"}, values)
+}
+
+func TestWillStopCollectingFieldWhenContextIsCancelled(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		cancel()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": [{"id": "1"}], "links": {"next": "https://connect.mailerlite.com/api/subscribers?cursor=next"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	_, _, err := client.Subscriber.CollectField(ctx, "company", nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCanCountSubscribersByStatus(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	calls := 0
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		assert.Equal(t, "https://connect.mailerlite.com/api/stats", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {"subscribed": 120, "unsubscribed": 15, "unconfirmed": 4, "bounced": 3, "junk_total": 1}
+			}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	counts, _, err := client.Subscriber.CountByStatus(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, map[string]int{
+		"active":       120,
+		"unsubscribed": 15,
+		"unconfirmed":  4,
+		"bounced":      3,
+		"junk":         1,
+	}, counts)
+}
+
+func TestCanTellCreatedFromUpdatedOnUpsert(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	status := http.StatusCreated
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "email": "test@test.com"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.UpsertSubscriberOptions{Email: "test@test.com"}
+
+	_, res, err := client.Subscriber.Upsert(context.TODO(), options)
+	assert.NoError(t, err)
+	assert.True(t, res.Created())
+
+	status = http.StatusOK
+
+	_, res, err = client.Subscriber.Upsert(context.TODO(), options)
+	assert.NoError(t, err)
+	assert.False(t, res.Created())
+}
+
+func TestCanForgetManySubscribers(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	var forgotten []string
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodPost {
+			forgotten = append(forgotten, req.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1"}}`)),
+			}
+		}
+
+		switch req.URL.Path {
+		case "/api/subscribers/first@example.com":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "email": "first@example.com"}}`)),
+			}
+		case "/api/subscribers/broken@example.com":
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Server Error"}`)),
+			}
+		default:
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Not found"}`)),
+			}
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	result, _, err := client.Subscriber.ForgetMany(context.TODO(), []string{"first@example.com", "missing@example.com", "broken@example.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first@example.com"}, result.Forgotten)
+	assert.Equal(t, []string{"missing@example.com"}, result.NotFound)
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors, "broken@example.com")
+	assert.Equal(t, []string{"/api/subscribers/1/forget"}, forgotten)
+}
+
+func TestCanExportSubscribersAsCSV(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	csv := "email,status\nclient@example.com,active\n"
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/subscribers?filter%5Bstatus%5D=active", req.URL.String())
+		assert.Equal(t, "text/csv", req.Header.Get("Accept"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Header:     http.Header{"Content-Type": []string{"text/csv"}},
+			Body:       io.NopCloser(strings.NewReader(csv)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSubscriberOptions{
+		Filters: &[]mailerlite.Filter{{Name: "status", Value: "active"}},
+	}
+
+	body, _, err := client.Subscriber.ExportCSV(context.TODO(), options)
+	assert.NoError(t, err)
+	defer body.Close()
+
+	export, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, csv, string(export))
+}
+
+func TestWillRejectExportThatIsNotCSV(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	body := &closeRecorder{Reader: strings.NewReader(`{"data": []}`)}
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       body,
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	export, _, err := client.Subscriber.ExportCSV(context.TODO(), nil)
+
+	assert.Nil(t, export)
+	assert.EqualError(t, err, `expected a text/csv response, got "application/json"`)
+	assert.True(t, body.closed)
+}
+
+func TestCanExportSubscribersAsNDJSON(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body := `{
+			"data": [{"id": "1", "email": "first@example.com"}, {"id": "2", "email": "second@example.com"}],
+			"links": {"next": "https://connect.mailerlite.com/api/subscribers?cursor=next"}
+		}`
+		if req.URL.Query().Get("cursor") == "next" {
+			body = `{"data": [{"id": "3", "email": "third@example.com"}], "links": {"next": null}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	var output bytes.Buffer
+	written, err := client.Subscriber.ExportNDJSON(context.TODO(), &output, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, written)
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Len(t, lines, 3)
+	for _, line := range lines {
+		var subscriber mailerlite.Subscriber
+		assert.NoError(t, json.Unmarshal([]byte(line), &subscriber))
+		assert.NotEmpty(t, subscriber.Email)
+	}
+}
+
+func TestCanListSubscribersByGroup(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	status := mailerlite.SubscriberStatusActive
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "/api/groups/1234/subscribers", req.URL.Path)
+		assert.Equal(t, string(status), req.URL.Query().Get("filter[status]"))
+		assert.Equal(t, "50", req.URL.Query().Get("limit"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": [{"id": "1", "status": "` + string(status) + `"}]}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.ListSubscriberOptions{ListOptions: mailerlite.ListOptions{Limit: 50}}
+
+	subscribers, _, err := client.Subscriber.ListByGroup(context.TODO(), "1234", "", options)
+	assert.NoError(t, err)
+	assert.Len(t, subscribers.Data, 1)
+
+	status = mailerlite.SubscriberStatusUnsubscribed
+
+	_, _, err = client.Subscriber.ListByGroup(context.TODO(), "1234", status, options)
+	assert.NoError(t, err)
+	assert.Nil(t, options.Filters)
+}
+
+func TestWillRejectInvalidSubscriberStatus(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		t.Fatal("the request should not be sent")
+		return nil
+	})
+
+	client.SetHttpClient(testClient)
+
+	filter, err := mailerlite.StatusFilter(mailerlite.SubscriberStatusBounced)
+	assert.NoError(t, err)
+	assert.Equal(t, "bounced", filter.Value)
+
+	_, err = mailerlite.StatusFilter("activ")
+	assert.EqualError(t, err, `invalid subscriber status "activ"`)
+
+	_, _, err = client.Subscriber.ListByGroup(context.TODO(), "1234", "activ", nil)
+	assert.EqualError(t, err, `invalid subscriber status "activ"`)
+
+	_, _, err = client.Group.UpdateSubscribersStatus(context.TODO(), "1234", "unsubscribe")
+	assert.EqualError(t, err, `invalid subscriber status "unsubscribe"`)
+
+	assert.True(t, mailerlite.SubscriberStatusJunk.IsValid())
+}
+
+func TestCanUseDeprecatedNewSubscriber(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"email": "client@example.com", "fields": {"last_name": "Doe"}, "groups": ["1"]}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "email": "client@example.com"}}`)),
+		}
+	})
+
+	client.SetHttpClient(testClient)
+
+	subscriber := (&mailerlite.NewSubscriber{
+		Email:    "client@example.com",
+		Fields:   mailerlite.Fields{LastName: "Doe"},
+		GroupIds: []string{"1"},
+	}).CreateOptions()
+
+	_, _, err := client.Subscriber.Create(context.TODO(), subscriber)
+	assert.NoError(t, err)
+
+	_, _, err = client.Subscriber.Upsert(context.TODO(), subscriber)
+	assert.NoError(t, err)
+}
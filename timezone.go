@@ -21,6 +21,7 @@ type Timezone struct {
 	Offset        int    `json:"offset"`
 }
 
+// List - list of timezones
 func (s *TimezoneService) List(ctx context.Context) (*rootTimezones, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, timezoneEndpoint, nil)
 	if err != nil {
@@ -0,0 +1,48 @@
+package mailerlite_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanListTimezones(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/timezones", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": [
+					{
+						"id": "1",
+						"name": "Europe/Vilnius",
+						"name_for_humans": "Vilnius",
+						"offset_name": "+03:00",
+						"offset": 10800
+					}
+				]
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	timezones, _, err := client.Timezone.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(timezones.Data))
+	assert.Equal(t, "Europe/Vilnius", timezones.Data[0].Name)
+	assert.Equal(t, "+03:00", timezones.Data[0].OffsetName)
+	assert.Equal(t, 10800, timezones.Data[0].Offset)
+}
@@ -2,12 +2,30 @@ package mailerlite
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const webhookEndpoint = "/webhooks"
 
+const (
+	WebhookEventSubscriberCreated           = "subscriber.created"
+	WebhookEventSubscriberUpdated           = "subscriber.updated"
+	WebhookEventSubscriberUnsubscribed      = "subscriber.unsubscribed"
+	WebhookEventSubscriberAddedToGroup      = "subscriber.added_to_group"
+	WebhookEventSubscriberRemovedFromGroup  = "subscriber.removed_from_group"
+	WebhookEventSubscriberBounced           = "subscriber.bounced"
+	WebhookEventSubscriberAutomationTrigger = "subscriber.automation_triggered"
+	WebhookEventSubscriberAutomationDone    = "subscriber.automation_completed"
+	WebhookEventSubscriberSpamReported      = "subscriber.spam_reported"
+	WebhookEventCampaignSent                = "campaign.sent"
+	WebhookEventCampaignOpen                = "campaign.open"
+	WebhookEventCampaignClick               = "campaign.click"
+)
+
 type WebhookService service
 
 type rootWebhook struct {
@@ -31,21 +49,73 @@ type Webhook struct {
 	UpdatedAt string   `json:"updated_at"`
 }
 
+// WebhookEvent is a payload MailerLite delivers to a webhook url
+type WebhookEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+
+	subscriber *Subscriber
+	campaign   *Campaign
+}
+
+// ParseWebhookEvent - parse a webhook payload and decode the object matching its type
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	event := new(WebhookEvent)
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	if event.Type == "" {
+		return nil, errors.New("webhook event has no type")
+	}
+
+	switch {
+	case strings.HasPrefix(event.Type, "subscriber."):
+		event.subscriber = new(Subscriber)
+		if err := json.Unmarshal(event.Data, event.subscriber); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(event.Type, "campaign."):
+		event.campaign = new(Campaign)
+		if err := json.Unmarshal(event.Data, event.campaign); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+// Subscriber - get the subscriber of a subscriber.* event
+func (e *WebhookEvent) Subscriber() (*Subscriber, error) {
+	if e.subscriber == nil {
+		return nil, fmt.Errorf("webhook event %s does not contain a subscriber", e.Type)
+	}
+	return e.subscriber, nil
+}
+
+// Campaign - get the campaign of a campaign.* event
+func (e *WebhookEvent) Campaign() (*Campaign, error) {
+	if e.campaign == nil {
+		return nil, fmt.Errorf("webhook event %s does not contain a campaign", e.Type)
+	}
+	return e.campaign, nil
+}
+
 // ListWebhookOptions - modifies the behavior of WebhookService.List method
 type ListWebhookOptions struct {
-	Sort  string `url:"sort,omitempty"`
-	Page  int    `url:"page,omitempty"`
-	Limit int    `url:"limit,omitempty"`
+	Sort string `url:"sort,omitempty"`
+	ListOptions
 }
 
 // CreateWebhookOptions - modifies the behavior of WebhookService.Create method
 type CreateWebhookOptions struct {
-	Name   string   `json:"name,omitempty"`
-	Events []string `json:"events"`
-	Url    string   `json:"url"`
+	Name    string   `json:"name,omitempty"`
+	Events  []string `json:"events"`
+	Url     string   `json:"url"`
+	Enabled *bool    `json:"enabled,omitempty"`
 }
 
-// UpdateWebhookOptions - modifies the behavior of WebhookService.Create method
+// UpdateWebhookOptions - modifies the behavior of WebhookService.Update method
 type UpdateWebhookOptions struct {
 	WebhookID string   `json:"-"`
 	Name      string   `json:"name,omitempty"`
@@ -54,6 +124,7 @@ type UpdateWebhookOptions struct {
 	Enabled   string   `json:"enabled,omitempty"`
 }
 
+// List - list of webhooks
 func (s *WebhookService) List(ctx context.Context, options *ListWebhookOptions) (*rootWebhooks, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, webhookEndpoint, options)
 	if err != nil {
@@ -69,8 +140,9 @@ func (s *WebhookService) List(ctx context.Context, options *ListWebhookOptions)
 	return root, res, nil
 }
 
+// Get - get a single webhook
 func (s *WebhookService) Get(ctx context.Context, webhookID string) (*rootWebhook, *Response, error) {
-	path := fmt.Sprintf("%s/%s", webhookEndpoint, webhookID)
+	path := buildPath(webhookEndpoint, webhookID)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -85,6 +157,7 @@ func (s *WebhookService) Get(ctx context.Context, webhookID string) (*rootWebhoo
 	return root, res, nil
 }
 
+// Create - create a new webhook, the response contains the secret used to sign payloads
 func (s *WebhookService) Create(ctx context.Context, options *CreateWebhookOptions) (*rootWebhook, *Response, error) {
 	req, err := s.client.newRequest(http.MethodPost, webhookEndpoint, options)
 	if err != nil {
@@ -100,8 +173,9 @@ func (s *WebhookService) Create(ctx context.Context, options *CreateWebhookOptio
 	return root, res, nil
 }
 
+// Update - update a webhook
 func (s *WebhookService) Update(ctx context.Context, options *UpdateWebhookOptions) (*rootWebhook, *Response, error) {
-	path := fmt.Sprintf("%s/%s", webhookEndpoint, options.WebhookID)
+	path := buildPath(webhookEndpoint, options.WebhookID)
 
 	req, err := s.client.newRequest(http.MethodPut, path, options)
 	if err != nil {
@@ -117,8 +191,32 @@ func (s *WebhookService) Update(ctx context.Context, options *UpdateWebhookOptio
 	return root, res, nil
 }
 
+// webhookEnabledUpdate is the body of SetEnabled, the pointer keeps false in the payload
+type webhookEnabledUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// SetEnabled - enable or disable a webhook without touching its other fields
+func (s *WebhookService) SetEnabled(ctx context.Context, webhookID string, enabled bool) (*Webhook, *Response, error) {
+	path := buildPath(webhookEndpoint, webhookID)
+
+	req, err := s.client.newRequest(http.MethodPut, path, &webhookEnabledUpdate{Enabled: Bool(enabled)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(rootWebhook)
+	res, err := s.client.do(ctx, req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &root.Data, res, nil
+}
+
+// Delete - delete a webhook
 func (s *WebhookService) Delete(ctx context.Context, webhookID string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", webhookEndpoint, webhookID)
+	path := buildPath(webhookEndpoint, webhookID)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
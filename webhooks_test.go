@@ -0,0 +1,156 @@
+package mailerlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanCreateWebhook(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/webhooks", req.URL.String())
+		assert.JSONEq(t, `{
+			"name": "Sync",
+			"events": ["subscriber.created", "subscriber.unsubscribed"],
+			"url": "https://example.com/hook",
+			"enabled": false
+		}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"id": "1",
+					"name": "Sync",
+					"url": "https://example.com/hook",
+					"events": ["subscriber.created", "subscriber.unsubscribed"],
+					"enabled": false,
+					"secret": "s3cr3t"
+				}
+			}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	options := &mailerlite.CreateWebhookOptions{
+		Name:    "Sync",
+		Events:  []string{"subscriber.created", "subscriber.unsubscribed"},
+		Url:     "https://example.com/hook",
+		Enabled: mailerlite.Bool(false),
+	}
+
+	webhook, _, err := client.Webhook.Create(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", webhook.Data.Secret)
+	assert.Equal(t, 2, len(webhook.Data.Events))
+}
+
+func TestCanDeleteWebhook(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/webhooks/1", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	res, err := client.Webhook.Delete(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestCanParseWebhookEvents(t *testing.T) {
+	created, err := mailerlite.ParseWebhookEvent([]byte(`{
+		"type": "subscriber.created",
+		"data": {"id": "123456789", "email": "client@example.com", "status": "active"}
+	}`))
+	assert.NoError(t, err)
+	subscriber, err := created.Subscriber()
+	assert.NoError(t, err)
+	assert.Equal(t, "client@example.com", subscriber.Email)
+	_, err = created.Campaign()
+	assert.Error(t, err)
+
+	unsubscribed, err := mailerlite.ParseWebhookEvent([]byte(`{
+		"type": "subscriber.unsubscribed",
+		"data": {"id": "123456789", "email": "client@example.com", "status": "unsubscribed"}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, mailerlite.WebhookEventSubscriberUnsubscribed, unsubscribed.Type)
+	subscriber, err = unsubscribed.Subscriber()
+	assert.NoError(t, err)
+	assert.Equal(t, mailerlite.SubscriberStatusUnsubscribed, subscriber.Status)
+
+	sent, err := mailerlite.ParseWebhookEvent([]byte(`{
+		"type": "campaign.sent",
+		"data": {"id": "1", "name": "Launch", "status": "sent"}
+	}`))
+	assert.NoError(t, err)
+	campaign, err := sent.Campaign()
+	assert.NoError(t, err)
+	assert.Equal(t, "Launch", campaign.Name)
+	_, err = sent.Subscriber()
+	assert.Error(t, err)
+
+	_, err = mailerlite.ParseWebhookEvent([]byte(`{"data": {}}`))
+	assert.Error(t, err)
+}
+
+func TestWillOmitUnsetWebhookEnabled(t *testing.T) {
+	body, err := json.Marshal(&mailerlite.CreateWebhookOptions{Name: "Hook", Events: []string{"subscriber.created"}, Url: "https://example.com"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "enabled")
+
+	body, err = json.Marshal(&mailerlite.CreateWebhookOptions{Events: []string{"subscriber.created"}, Url: "https://example.com", Enabled: mailerlite.Bool(false)})
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"enabled":false`)
+}
+
+func TestCanSetWebhookEnabled(t *testing.T) {
+	client := mailerlite.NewClient(testKey)
+
+	testClient := NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://connect.mailerlite.com/api/webhooks/1", req.URL.String())
+		assert.JSONEq(t, `{"enabled": false}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`{"data": {"id": "1", "name": "Sync", "enabled": false}}`)),
+		}
+	})
+
+	ctx := context.TODO()
+
+	client.SetHttpClient(testClient)
+
+	webhook, _, err := client.Webhook.SetEnabled(ctx, "1", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", webhook.Id)
+	assert.False(t, webhook.Enabled)
+}